@@ -9,9 +9,12 @@ import (
 	"syscall"
 	"time"
 
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/joho/godotenv"
+	"telegram-job/internal/bot"
 	"telegram-job/internal/config"
 	"telegram-job/internal/handler"
+	"telegram-job/internal/publisher"
 	"telegram-job/internal/repository"
 	"telegram-job/internal/service"
 )
@@ -40,15 +43,39 @@ func main() {
 	jobRepo := repository.NewJobRepository(db)
 	companyRepo := repository.NewCompanyRepository(db)
 	userRepo := repository.NewUserRepository(db)
+	revisionRepo := repository.NewRevisionRepository(db)
+	eventRepo := repository.NewPostEventRepository(db)
+	outboxRepo := repository.NewPublishOutboxRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	promoRepo := repository.NewPromoCodeRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	draftRepo := repository.NewDraftRepository(db)
 
-	// Initialize service (publisher and notifier will be set by bot)
-	jobService := service.NewJobService(cfg, jobRepo, companyRepo, userRepo, nil, nil)
+	// Bot API client for sending only; the bot process receives the updates.
+	// Posts submitted over the API reach admins the same way as from the bot.
+	botAPI, err := tgbotapi.NewBotAPI(cfg.BotToken)
+	if err != nil {
+		log.Fatalf("Failed to create bot API client: %v", err)
+	}
+	channelPublisher := publisher.NewChannelPublisher(botAPI, cfg)
+	adminNotifier := bot.NewAdminNotifier(botAPI, cfg.AdminTelegramIDs)
+
+	// Initialize service with publisher and notifier
+	jobService := service.NewJobService(cfg, jobRepo, companyRepo, userRepo, revisionRepo, eventRepo, outboxRepo, paymentRepo, promoRepo, channelPublisher, adminNotifier)
+	paymentService := service.NewPaymentService(cfg, paymentRepo, jobRepo, promoRepo)
+	postService := service.NewPostService(cfg, jobRepo)
+	authService := service.NewAuthService(cfg, apiKeyRepo)
+	draftService := service.NewDraftService(draftRepo, jobService)
 
 	// Initialize handlers
+	authenticator := handler.NewAuthenticator(authService)
 	jobHandler := handler.NewJobHandler(jobService)
+	paymentHandler := handler.NewPaymentHandler(paymentService)
+	postHandler := handler.NewPostHandler(postService)
+	draftHandler := handler.NewDraftHandler(cfg, draftService)
 
 	// Create router
-	router := handler.NewRouter(jobHandler)
+	router := handler.NewRouter(authenticator, jobHandler, paymentHandler, postHandler, draftHandler)
 
 	// Create server
 	server := &http.Server{
@@ -41,6 +41,15 @@ func main() {
 	jobRepo := repository.NewJobRepository(db)
 	companyRepo := repository.NewCompanyRepository(db)
 	userRepo := repository.NewUserRepository(db)
+	revisionRepo := repository.NewRevisionRepository(db)
+	eventRepo := repository.NewPostEventRepository(db)
+	outboxRepo := repository.NewPublishOutboxRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	packageRepo := repository.NewPackageRepository(db)
+	promoRepo := repository.NewPromoCodeRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	fsmStateRepo := repository.NewFSMStateRepository(db)
 
 	// Initialize bot first (to get bot API)
 	telegramBot, err := bot.New(cfg, nil, userRepo)
@@ -48,20 +57,49 @@ func main() {
 		log.Fatalf("Failed to create bot: %v", err)
 	}
 
+	// Keep FSM drafts in PostgreSQL so restarts don't lose them
+	telegramBot.SetStateStore(bot.NewPostgresStateStore(fsmStateRepo))
+
 	// Initialize publisher and notifier with the same bot API
-	channelPublisher := publisher.NewChannelPublisher(telegramBot.GetAPI(), cfg.ChannelID)
+	channelPublisher := publisher.NewChannelPublisher(telegramBot.GetAPI(), cfg)
 	adminNotifier := bot.NewAdminNotifier(telegramBot.GetAPI(), cfg.AdminTelegramIDs)
 
 	// Initialize service with publisher and notifier
-	jobService := service.NewJobService(cfg, jobRepo, companyRepo, userRepo, channelPublisher, adminNotifier)
+	jobService := service.NewJobService(cfg, jobRepo, companyRepo, userRepo, revisionRepo, eventRepo, outboxRepo, paymentRepo, promoRepo, channelPublisher, adminNotifier)
 
 	// Set service to bot (use same bot instance!)
 	telegramBot.SetJobService(jobService)
+	telegramBot.SetPaymentService(service.NewPaymentService(cfg, paymentRepo, jobRepo, promoRepo))
+	telegramBot.SetPackageService(service.NewPackageService(cfg, packageRepo, userRepo))
+	telegramBot.SetPromoService(service.NewPromoService(cfg, promoRepo))
+	telegramBot.SetSubscriptionService(service.NewSubscriptionService(subscriptionRepo))
+	telegramBot.SetAuthService(service.NewAuthService(cfg, apiKeyRepo))
+	telegramBot.SetDigestService(service.NewDigestService(cfg, subscriptionRepo, jobRepo, channelPublisher))
 
 	// Start cleanup service (auto-archive old jobs)
 	cleanupService := bot.NewCleanupService(jobRepo, channelPublisher, cfg.JobMaxDays)
 	go cleanupService.Start(ctx)
 
+	// Start draft reminder service (remind about and expire abandoned drafts)
+	draftService := bot.NewDraftReminderService(telegramBot, cfg.DraftTTLHours, cfg.DraftRemindHours)
+	go draftService.Start(ctx)
+
+	// Start publish worker (sends approved and scheduled posts to the channel)
+	publishWorker := bot.NewPublishWorker(telegramBot)
+	go publishWorker.Start(ctx)
+
+	// Start unpin service (ends the pin window of featured posts)
+	unpinService := bot.NewUnpinService(jobService)
+	go unpinService.Start(ctx)
+
+	// Start alert worker (forwards new posts to matching subscribers)
+	alertWorker := bot.NewAlertWorker(telegramBot)
+	go alertWorker.Start(ctx)
+
+	// Start digest worker (daily and weekly summaries, weekly channel digest)
+	digestWorker := bot.NewDigestWorker(telegramBot)
+	go digestWorker.Start(ctx)
+
 	// Graceful shutdown
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -0,0 +1,79 @@
+// Package auth verifies the identity of HTTP API callers
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInitData = errors.New("invalid init data")
+	ErrExpiredInitData = errors.New("init data expired")
+)
+
+// WebAppUser is the user a Telegram Mini App was opened by
+type WebAppUser struct {
+	ID           int64  `json:"id"`
+	Username     string `json:"username"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	LanguageCode string `json:"language_code"`
+}
+
+// VerifyInitData checks the signature of Telegram WebApp initData
+// (https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app)
+// and returns its user. Data signed more than maxAge ago is rejected.
+func VerifyInitData(initData, botToken string, maxAge time.Duration, now time.Time) (*WebAppUser, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, ErrInvalidInitData
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, ErrInvalidInitData
+	}
+
+	// data-check-string: all fields but hash as key=value, sorted, joined by \n
+	pairs := make([]string, 0, len(values))
+	for key := range values {
+		if key != "hash" {
+			pairs = append(pairs, key+"="+values.Get(key))
+		}
+	}
+	sort.Strings(pairs)
+
+	secret := hmacSHA256([]byte("WebAppData"), []byte(botToken))
+	expected := hex.EncodeToString(hmacSHA256(secret, []byte(strings.Join(pairs, "\n"))))
+	if !hmac.Equal([]byte(expected), []byte(hash)) {
+		return nil, ErrInvalidInitData
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidInitData
+	}
+	if now.Sub(time.Unix(authDate, 0)) > maxAge {
+		return nil, ErrExpiredInitData
+	}
+
+	var user WebAppUser
+	if err := json.Unmarshal([]byte(values.Get("user")), &user); err != nil || user.ID == 0 {
+		return nil, ErrInvalidInitData
+	}
+	return &user, nil
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
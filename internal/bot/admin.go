@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/google/uuid"
 	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
 )
 
 // AdminNotifier sends notifications to admins about new jobs
@@ -29,7 +32,48 @@ func (n *AdminNotifier) NotifyNewJob(ctx context.Context, post *domain.PostWithD
 	log.Printf("Admin IDs to notify: %v", n.adminIDs)
 
 	text := formatAdminNotification(post)
+	keyboard := moderationKeyboard(post)
 
+	for adminID := range n.adminIDs {
+		log.Printf("Sending notification to admin %d", adminID)
+		msg := tgbotapi.NewMessage(adminID, text)
+		msg.ParseMode = "Markdown"
+		msg.ReplyMarkup = keyboard
+		resp, err := n.bot.Send(msg)
+		if err != nil {
+			log.Printf("Error sending to admin %d: %v", adminID, err)
+		} else {
+			log.Printf("Sent to admin %d, message ID: %d", adminID, resp.MessageID)
+		}
+	}
+
+	return nil
+}
+
+// NotifyPaid re-sends the moderation card after the author paid with a Telegram invoice
+func (n *AdminNotifier) NotifyPaid(ctx context.Context, post *domain.PostWithDetails, payment *domain.Payment) error {
+	tier := domain.PlacementStandard
+	if payment.Tier != nil {
+		tier = *payment.Tier
+	}
+	text := fmt.Sprintf("💳 *Paid via Telegram:* %s %s, %s placement\n\n", formatAmount(payment.Amount), payment.Currency, tier) +
+		formatAdminNotification(post)
+	keyboard := moderationKeyboard(post)
+
+	for adminID := range n.adminIDs {
+		msg := tgbotapi.NewMessage(adminID, text)
+		msg.ParseMode = "Markdown"
+		msg.ReplyMarkup = keyboard
+		if _, err := n.bot.Send(msg); err != nil {
+			log.Printf("Error sending paid post to admin %d: %v", adminID, err)
+		}
+	}
+
+	return nil
+}
+
+// moderationKeyboard is the Contact / Approve / Reject keyboard of a moderation card
+func moderationKeyboard(post *domain.PostWithDetails) tgbotapi.InlineKeyboardMarkup {
 	var keyboardRows [][]tgbotapi.InlineKeyboardButton
 
 	// Contact button
@@ -46,31 +90,208 @@ func (n *AdminNotifier) NotifyNewJob(ctx context.Context, post *domain.PostWithD
 	// Approve/Reject buttons
 	keyboardRows = append(keyboardRows, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData("✅ Approve", "approve:"+post.ID.String()),
+		tgbotapi.NewInlineKeyboardButtonData("⭐ Approve Featured", "approve_featured:"+post.ID.String()),
 		tgbotapi.NewInlineKeyboardButtonData("❌ Reject", "reject:"+post.ID.String()),
 	))
+	keyboardRows = append(keyboardRows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🕒 Approve (publish later)", "schedule:"+post.ID.String()),
+	))
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(keyboardRows...)
+	return tgbotapi.NewInlineKeyboardMarkup(keyboardRows...)
+}
+
+// scheduleDelays are the "publish later" options offered to admins, in hours
+var scheduleDelays = []int{1, 3, 12, 24}
+
+// scheduleKeyboard replaces Approve/Reject on the card while the admin picks a
+// publication time, for a standard or a featured placement
+func scheduleKeyboard(postID string) tgbotapi.InlineKeyboardMarkup {
+	var standard, featured []tgbotapi.InlineKeyboardButton
+	for _, hours := range scheduleDelays {
+		standard = append(standard, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("+%dh", hours), fmt.Sprintf("schedule_at:%s:%d:%s", postID, hours, domain.PlacementStandard)))
+		featured = append(featured, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("⭐ +%dh", hours), fmt.Sprintf("schedule_at:%s:%d:%s", postID, hours, domain.PlacementFeatured)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		standard,
+		featured,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⬅️ Back", "schedule_back:"+postID),
+		),
+	)
+}
+
+// rejectReason is a preset rejection reason, stored in the post's language
+type rejectReason struct {
+	Code  string
+	Label string // Admin button (English, like the rest of the admin UI)
+	EN    string
+	RU    string
+}
+
+var rejectReasons = []rejectReason{
+	{Code: "incomplete", Label: "📝 Incomplete info", EN: "The post is missing important details", RU: "В публикации не хватает важной информации"},
+	{Code: "offtopic", Label: "🚫 Not IT-related", EN: "The post is not related to IT", RU: "Публикация не относится к IT"},
+	{Code: "duplicate", Label: "♻️ Duplicate", EN: "The same post has already been submitted", RU: "Такая публикация уже была отправлена"},
+	{Code: "spam", Label: "⚠️ Spam / suspicious", EN: "The post looks like spam or is suspicious", RU: "Публикация похожа на спам или вызывает подозрения"},
+}
+
+// rejectReasonText returns the preset reason text in the post's language
+func rejectReasonText(code string, postLanguage string) (string, bool) {
+	for _, r := range rejectReasons {
+		if r.Code == code {
+			if postLanguage == "en" {
+				return r.EN, true
+			}
+			return r.RU, true
+		}
+	}
+	return "", false
+}
+
+// rejectReasonKeyboard replaces Approve/Reject on the card while the admin picks a reason
+func rejectReasonKeyboard(postID string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i := 0; i < len(rejectReasons); i += 2 {
+		row := []tgbotapi.InlineKeyboardButton{
+			tgbotapi.NewInlineKeyboardButtonData(rejectReasons[i].Label, "reject_reason:"+postID+":"+rejectReasons[i].Code),
+		}
+		if i+1 < len(rejectReasons) {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(rejectReasons[i+1].Label, "reject_reason:"+postID+":"+rejectReasons[i+1].Code))
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("✍️ Other reason", "reject_other:"+postID),
+		tgbotapi.NewInlineKeyboardButtonData("⬅️ Back", "reject_back:"+postID),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// NotifyRevision asks admins to review an author's edit of a published post
+func (n *AdminNotifier) NotifyRevision(ctx context.Context, revision *domain.PostRevision) error {
+	log.Printf("NotifyRevision called for post %s (revision %s)", revision.PostID, revision.ID)
+
+	text := "✏️ *Edit of a published post*\n\n" + formatAdminNotification(&revision.Content)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Approve edit", "approve_rev:"+revision.ID.String()),
+			tgbotapi.NewInlineKeyboardButtonData("❌ Reject edit", "reject_rev:"+revision.ID.String()),
+		),
+	)
 
 	for adminID := range n.adminIDs {
-		log.Printf("Sending notification to admin %d", adminID)
 		msg := tgbotapi.NewMessage(adminID, text)
 		msg.ParseMode = "Markdown"
 		msg.ReplyMarkup = keyboard
-		resp, err := n.bot.Send(msg)
-		if err != nil {
-			log.Printf("Error sending to admin %d: %v", adminID, err)
+		if _, err := n.bot.Send(msg); err != nil {
+			log.Printf("Error sending revision to admin %d: %v", adminID, err)
+		}
+	}
+
+	return nil
+}
+
+// NotifyAuthorRevision tells the author whether their edit was applied
+func (n *AdminNotifier) NotifyAuthorRevision(authorTelegramID int64, approved bool, postTitle string, postLanguage string) {
+	var text string
+	if postLanguage == "en" {
+		if approved {
+			text = fmt.Sprintf("✅ *Your changes have been approved*\n\n"+
+				"Post *%s* has been updated in @BridgeJob", escapeMarkdownAdmin(postTitle))
 		} else {
-			log.Printf("Sent to admin %d, message ID: %d", adminID, resp.MessageID)
+			text = fmt.Sprintf("❌ *Your changes have been rejected*\n\n"+
+				"Post *%s* stays as it was. You can edit it again: /myjobs", escapeMarkdownAdmin(postTitle))
+		}
+	} else {
+		if approved {
+			text = fmt.Sprintf("✅ *Ваши изменения одобрены*\n\n"+
+				"Публикация *%s* обновлена в @BridgeJob", escapeMarkdownAdmin(postTitle))
+		} else {
+			text = fmt.Sprintf("❌ *Ваши изменения отклонены*\n\n"+
+				"Публикация *%s* осталась без изменений. Можно отредактировать снова: /myjobs", escapeMarkdownAdmin(postTitle))
+		}
+	}
+
+	msg := tgbotapi.NewMessage(authorTelegramID, text)
+	msg.ParseMode = "Markdown"
+	n.bot.Send(msg)
+}
+
+// NotifyPostClosed informs admins (for audit) that an author withdrew a post
+func (n *AdminNotifier) NotifyPostClosed(ctx context.Context, post *domain.PostWithDetails) error {
+	text := fmt.Sprintf("🔒 *Post closed by author*\n\n"+
+		"*%s*\n"+
+		"Reason: %s\n"+
+		"Author ID: `%d`\n\n"+
+		"———\n"+
+		"Post ID: `%s`",
+		escapeMarkdownAdmin(post.Title),
+		escapeMarkdownAdmin(post.StatusReason),
+		post.AuthorTelegramID,
+		post.ID.String(),
+	)
+
+	for adminID := range n.adminIDs {
+		msg := tgbotapi.NewMessage(adminID, text)
+		msg.ParseMode = "Markdown"
+		if _, err := n.bot.Send(msg); err != nil {
+			log.Printf("Error sending close notice to admin %d: %v", adminID, err)
+		}
+	}
+
+	return nil
+}
+
+// NotifyPublishFailed tells admins that a post could not be sent to the channel
+func (n *AdminNotifier) NotifyPublishFailed(ctx context.Context, post *domain.PostWithDetails, reason string) error {
+	text := fmt.Sprintf("⚠️ *Failed to publish post*\n\n"+
+		"*%s*\n"+
+		"Error: %s\n\n"+
+		"The post stays approved and won't be retried automatically. "+
+		"Press *Retry publishing* once the problem is fixed.\n\n"+
+		"———\n"+
+		"Post ID: `%s`",
+		escapeMarkdownAdmin(post.Title),
+		escapeMarkdownAdmin(reason),
+		post.ID.String(),
+	)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔁 Retry publishing", "retry_publish:"+post.ID.String()),
+		),
+	)
+
+	for adminID := range n.adminIDs {
+		msg := tgbotapi.NewMessage(adminID, text)
+		msg.ParseMode = "Markdown"
+		msg.ReplyMarkup = keyboard
+		if _, err := n.bot.Send(msg); err != nil {
+			log.Printf("Error sending publish failure to admin %d: %v", adminID, err)
 		}
 	}
 
 	return nil
 }
 
-func (n *AdminNotifier) NotifyAuthor(authorTelegramID int64, approved bool, postTitle string, postLanguage string, postType domain.PostType) {
+// NotifyAuthor tells the author about the moderation result. reason is only
+// used for rejections and may be empty.
+func (n *AdminNotifier) NotifyAuthor(authorTelegramID int64, approved bool, postTitle string, postLanguage string, postType domain.PostType, reason string) {
 	var text string
 	isResume := postType == domain.PostTypeResume
 
+	reasonLine := ""
+	if reason != "" {
+		if postLanguage == "en" {
+			reasonLine = fmt.Sprintf("*Reason:* %s\n\n", escapeMarkdownAdmin(reason))
+		} else {
+			reasonLine = fmt.Sprintf("*Причина:* %s\n\n", escapeMarkdownAdmin(reason))
+		}
+	}
+
 	if postLanguage == "en" {
 		if approved {
 			if isResume {
@@ -87,12 +308,12 @@ func (n *AdminNotifier) NotifyAuthor(authorTelegramID int64, approved bool, post
 		} else {
 			if isResume {
 				text = fmt.Sprintf("❌ *Your resume has been rejected*\n\n"+
-					"Resume *%s* did not pass moderation.\n\n"+
+					"Resume *%s* did not pass moderation.\n\n"+reasonLine+
 					"Please try again with correct data: /post\\_job\n\n"+
 					"📢 Channel: @BridgeJob", escapeMarkdownAdmin(postTitle))
 			} else {
 				text = fmt.Sprintf("❌ *Your job has been rejected*\n\n"+
-					"Job *%s* did not pass moderation.\n\n"+
+					"Job *%s* did not pass moderation.\n\n"+reasonLine+
 					"Please try again with correct data: /post\\_job\n\n"+
 					"📢 Channel: @BridgeJob", escapeMarkdownAdmin(postTitle))
 			}
@@ -113,12 +334,12 @@ func (n *AdminNotifier) NotifyAuthor(authorTelegramID int64, approved bool, post
 		} else {
 			if isResume {
 				text = fmt.Sprintf("❌ *Ваше резюме отклонено*\n\n"+
-					"Резюме *%s* не прошло модерацию.\n\n"+
+					"Резюме *%s* не прошло модерацию.\n\n"+reasonLine+
 					"Попробуйте отправить заново с корректными данными: /post\\_job\n\n"+
 					"📢 Канал: @BridgeJob", escapeMarkdownAdmin(postTitle))
 			} else {
 				text = fmt.Sprintf("❌ *Ваша вакансия отклонена*\n\n"+
-					"Вакансия *%s* не прошла модерацию.\n\n"+
+					"Вакансия *%s* не прошла модерацию.\n\n"+reasonLine+
 					"Попробуйте отправить заново с корректными данными: /post\\_job\n\n"+
 					"📢 Канал: @BridgeJob", escapeMarkdownAdmin(postTitle))
 			}
@@ -130,6 +351,36 @@ func (n *AdminNotifier) NotifyAuthor(authorTelegramID int64, approved bool, post
 	n.bot.Send(msg)
 }
 
+// NotifyRejected tells the author their post was rejected and why
+func (n *AdminNotifier) NotifyRejected(ctx context.Context, post *domain.PostWithDetails, reason string) error {
+	n.NotifyAuthor(post.AuthorTelegramID, false, post.Title, post.Language, post.PostType, reason)
+	return nil
+}
+
+// NotifyScheduled tells the author their post is approved for publishing at publishAt
+func (n *AdminNotifier) NotifyScheduled(ctx context.Context, post *domain.PostWithDetails, publishAt time.Time) error {
+	n.NotifyAuthorScheduled(post.AuthorTelegramID, post.Title, post.Language, publishAt)
+	return nil
+}
+
+// NotifyAuthorScheduled tells the author their post is approved and when it goes live
+func (n *AdminNotifier) NotifyAuthorScheduled(authorTelegramID int64, postTitle string, postLanguage string, publishAt time.Time) {
+	when := publishAt.UTC().Format("02.01.2006 15:04") + " UTC"
+
+	var text string
+	if postLanguage == "en" {
+		text = fmt.Sprintf("✅ *Your post has been approved!*\n\n"+
+			"*%s* will be published in @BridgeJob on %s", escapeMarkdownAdmin(postTitle), when)
+	} else {
+		text = fmt.Sprintf("✅ *Ваша публикация одобрена!*\n\n"+
+			"*%s* будет опубликована в @BridgeJob %s", escapeMarkdownAdmin(postTitle), when)
+	}
+
+	msg := tgbotapi.NewMessage(authorTelegramID, text)
+	msg.ParseMode = "Markdown"
+	n.bot.Send(msg)
+}
+
 func (n *AdminNotifier) NotifyAuthorDeleted(authorTelegramID int64, postTitle string, postLanguage string, postType domain.PostType) {
 	var text string
 	isResume := postType == domain.PostTypeResume
@@ -192,6 +443,14 @@ func formatAdminNotification(post *domain.PostWithDetails) string {
 		langDisplay = "🇬🇧 EN"
 	}
 
+	paid := ""
+	if post.Paid {
+		paid = "\n💳 *PAID*"
+	}
+	if post.PromoCode != "" {
+		paid += "\n🏷 *Promo code:* " + escapeMarkdownAdmin(post.PromoCode)
+	}
+
 	// Resume format
 	if post.PostType == domain.PostTypeResume {
 		experience := "Not specified"
@@ -205,7 +464,7 @@ func formatAdminNotification(post *domain.PostWithDetails) string {
 			resumeLink = post.ResumeLink
 		}
 
-		return fmt.Sprintf(`👤 *New Resume for Moderation*
+		return fmt.Sprintf(`👤 *New Resume for Moderation*%s
 
 🌐 *Language:* %s
 💼 *Position:* %s
@@ -222,6 +481,7 @@ func formatAdminNotification(post *domain.PostWithDetails) string {
 
 ———
 Resume ID: `+"`%s`",
+			paid,
 			langDisplay,
 			escapeMarkdownAdmin(post.Title),
 			levelDisplay,
@@ -237,7 +497,7 @@ Resume ID: `+"`%s`",
 	}
 
 	// Vacancy format
-	return fmt.Sprintf(`🏢 *New Vacancy for Moderation*
+	return fmt.Sprintf(`🏢 *New Vacancy for Moderation*%s
 
 🌐 *Language:* %s
 🏢 *Company:* %s
@@ -254,6 +514,7 @@ Resume ID: `+"`%s`",
 
 ———
 Job ID: `+"`%s`",
+		paid,
 		langDisplay,
 		escapeMarkdownAdmin(post.CompanyName),
 		escapeMarkdownAdmin(post.Title),
@@ -285,31 +546,39 @@ func (b *Bot) handleAdminCallback(callback *tgbotapi.CallbackQuery) {
 
 	ctx := context.Background()
 
-	// Handle approve
-	if strings.HasPrefix(data, "approve:") {
-		jobIDStr := strings.TrimPrefix(data, "approve:")
+	// Handle approve (standard or featured placement)
+	if strings.HasPrefix(data, "approve:") || strings.HasPrefix(data, "approve_featured:") {
+		tier := domain.PlacementStandard
+		status := "✅ APPROVED, QUEUED FOR PUBLISHING"
+		if strings.HasPrefix(data, "approve_featured:") {
+			tier = domain.PlacementFeatured
+			status = "⭐ APPROVED AS FEATURED, QUEUED FOR PUBLISHING"
+		}
+		jobIDStr := strings.TrimPrefix(strings.TrimPrefix(data, "approve:"), "approve_featured:")
 		jobID, err := uuid.Parse(jobIDStr)
 		if err != nil {
 			b.sendMessage(chatID, "Invalid job ID")
 			return
 		}
 
-		// Получаем данные о вакансии до approve
-		jobInfo, _ := b.jobService.GetJobWithCompany(ctx, jobID)
-
-		err = b.jobService.ApproveJob(ctx, jobID, adminID)
+		err = b.jobService.ApproveJob(ctx, jobID, botPrincipal(adminID), tier)
 		if err != nil {
 			// Если вакансия уже обработана - не показываем ошибку
 			if err.Error() == "invalid status transition" {
 				log.Printf("Job %s already processed", jobIDStr)
 				return
 			}
+			if err == service.ErrPaymentRequired {
+				b.sendMessage(chatID, "💳 This post has no paid payment yet. Record one with /payment and mark it paid first.")
+				return
+			}
 			b.sendMessage(chatID, "Failed to approve: "+err.Error())
 			return
 		}
 
-		// Обновляем сообщение с кнопкой удаления
-		newText := callback.Message.Text + "\n\n✅ APPROVED & PUBLISHED"
+		// Обновляем сообщение с кнопкой удаления.
+		// Публикует и уведомляет автора PublishWorker.
+		newText := callback.Message.Text + "\n\n" + status
 		deleteKeyboard := tgbotapi.NewInlineKeyboardMarkup(
 			tgbotapi.NewInlineKeyboardRow(
 				tgbotapi.NewInlineKeyboardButtonData("🗑 Delete from channel", "delete:"+jobIDStr),
@@ -321,48 +590,214 @@ func (b *Bot) handleAdminCallback(callback *tgbotapi.CallbackQuery) {
 			log.Printf("Error editing message after approve: %v", err)
 		}
 
-		// Уведомляем автора
-		if jobInfo != nil {
-			notifier := NewAdminNotifier(b.api, b.cfg.AdminTelegramIDs)
-			notifier.NotifyAuthor(jobInfo.AuthorTelegramID, true, jobInfo.Title, jobInfo.Language, jobInfo.PostType)
-		}
-
 		return
 	}
 
-	// Handle reject
+	// Handle reject: ask for a reason
 	if strings.HasPrefix(data, "reject:") {
 		jobIDStr := strings.TrimPrefix(data, "reject:")
-		jobID, err := uuid.Parse(jobIDStr)
+		edit := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, rejectReasonKeyboard(jobIDStr))
+		if _, err := b.api.Send(edit); err != nil {
+			log.Printf("Error showing reject reasons: %v", err)
+		}
+		return
+	}
+
+	// Handle preset reject reason
+	if strings.HasPrefix(data, "reject_reason:") {
+		parts := strings.SplitN(strings.TrimPrefix(data, "reject_reason:"), ":", 2)
+		if len(parts) != 2 {
+			return
+		}
+		jobID, err := uuid.Parse(parts[0])
 		if err != nil {
 			b.sendMessage(chatID, "Invalid job ID")
 			return
 		}
 
-		// Получаем данные о публикации до reject
-		jobInfo, _ := b.jobService.GetJobWithCompany(ctx, jobID)
-
-		err = b.jobService.RejectJob(ctx, jobID, adminID, "Rejected by admin")
+		jobInfo, err := b.jobService.GetJobWithCompany(ctx, jobID)
 		if err != nil {
-			b.sendMessage(chatID, "Failed to reject: "+err.Error())
+			b.sendMessage(chatID, "Job not found")
+			return
+		}
+		reason, ok := rejectReasonText(parts[1], jobInfo.Language)
+		if !ok {
+			b.sendMessage(chatID, "Unknown reason")
+			return
+		}
+
+		b.fsm.SetRejectPostID(adminID, "")
+		if !b.rejectPost(ctx, chatID, adminID, jobInfo, reason) {
 			return
 		}
 
 		// Обновляем сообщение без кнопок
-		newText := callback.Message.Text + "\n\n❌ REJECTED"
+		newText := callback.Message.Text + "\n\n❌ REJECTED: " + reason
 		edit := tgbotapi.NewEditMessageText(chatID, messageID, newText)
-		edit.ParseMode = "Markdown"
-		_, err = b.api.Send(edit)
-		if err != nil {
+		if _, err := b.api.Send(edit); err != nil {
 			log.Printf("Error editing message after reject: %v", err)
 		}
+		return
+	}
 
-		// Уведомляем автора
-		if jobInfo != nil {
-			notifier := NewAdminNotifier(b.api, b.cfg.AdminTelegramIDs)
-			notifier.NotifyAuthor(jobInfo.AuthorTelegramID, false, jobInfo.Title, jobInfo.Language, jobInfo.PostType)
+	// Handle free-text reject reason: wait for the admin's next message
+	if strings.HasPrefix(data, "reject_other:") {
+		jobIDStr := strings.TrimPrefix(data, "reject_other:")
+		if _, err := uuid.Parse(jobIDStr); err != nil {
+			b.sendMessage(chatID, "Invalid job ID")
+			return
+		}
+		b.fsm.SetRejectPostID(adminID, jobIDStr)
+		b.sendMessage(chatID, "✍️ Send the rejection reason as a message. It will be shown to the author as is.\n\n/cancel to abort")
+		return
+	}
+
+	// Handle approve with scheduled publication: ask when
+	if strings.HasPrefix(data, "schedule:") {
+		jobIDStr := strings.TrimPrefix(data, "schedule:")
+		edit := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, scheduleKeyboard(jobIDStr))
+		if _, err := b.api.Send(edit); err != nil {
+			log.Printf("Error showing schedule options: %v", err)
+		}
+		return
+	}
+
+	// Handle chosen publication time
+	if strings.HasPrefix(data, "schedule_at:") {
+		// schedule_at:<post>:<hours>:<tier>; buttons sent before the tier was added are standard
+		parts := strings.SplitN(strings.TrimPrefix(data, "schedule_at:"), ":", 3)
+		if len(parts) < 2 {
+			return
+		}
+		tier := domain.PlacementStandard
+		if len(parts) == 3 {
+			tier = domain.PlacementTier(parts[2])
+		}
+		if tier != domain.PlacementStandard && tier != domain.PlacementFeatured {
+			return
+		}
+		jobID, err := uuid.Parse(parts[0])
+		if err != nil {
+			b.sendMessage(chatID, "Invalid job ID")
+			return
+		}
+		hours, err := strconv.Atoi(parts[1])
+		if err != nil || hours <= 0 {
+			return
+		}
+
+		// JobService tells the author when the post goes live
+		publishAt := time.Now().UTC().Add(time.Duration(hours) * time.Hour)
+		err = b.jobService.ScheduleJob(ctx, jobID, botPrincipal(adminID), tier, publishAt)
+		if err == service.ErrInvalidTransition {
+			log.Printf("Job %s already processed", jobID)
+			return
+		}
+		if err == service.ErrPaymentRequired {
+			b.sendMessage(chatID, "💳 This post has no paid payment yet. Record one with /payment and mark it paid first.")
+			return
+		}
+		if err != nil {
+			b.sendMessage(chatID, "Failed to schedule: "+err.Error())
+			return
+		}
+
+		status := "🕒 APPROVED, PUBLISHING AT "
+		if tier == domain.PlacementFeatured {
+			status = "⭐ APPROVED AS FEATURED, PUBLISHING AT "
+		}
+		newText := callback.Message.Text + "\n\n" + status + publishAt.Format("2006-01-02 15:04") + " UTC"
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, newText)
+		if _, err := b.api.Send(edit); err != nil {
+			log.Printf("Error editing message after schedule: %v", err)
+		}
+		return
+	}
+
+	// Handle back from reject reasons / schedule options
+	if strings.HasPrefix(data, "reject_back:") || strings.HasPrefix(data, "schedule_back:") {
+		jobIDStr := strings.TrimPrefix(strings.TrimPrefix(data, "reject_back:"), "schedule_back:")
+		jobID, err := uuid.Parse(jobIDStr)
+		if err != nil {
+			b.sendMessage(chatID, "Invalid job ID")
+			return
+		}
+		jobInfo, err := b.jobService.GetJobWithCompany(ctx, jobID)
+		if err != nil {
+			b.sendMessage(chatID, "Job not found")
+			return
+		}
+		b.fsm.SetRejectPostID(adminID, "")
+		edit := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, moderationKeyboard(jobInfo))
+		if _, err := b.api.Send(edit); err != nil {
+			log.Printf("Error restoring moderation buttons: %v", err)
+		}
+		return
+	}
+
+	// Handle revision approve/reject
+	if strings.HasPrefix(data, "approve_rev:") || strings.HasPrefix(data, "reject_rev:") {
+		approve := strings.HasPrefix(data, "approve_rev:")
+		revIDStr := strings.TrimPrefix(strings.TrimPrefix(data, "approve_rev:"), "reject_rev:")
+		revID, err := uuid.Parse(revIDStr)
+		if err != nil {
+			b.sendMessage(chatID, "Invalid revision ID")
+			return
+		}
+
+		var post *domain.PostWithDetails
+		if approve {
+			post, err = b.jobService.ApproveRevision(ctx, revID, botPrincipal(adminID))
+		} else {
+			post, err = b.jobService.RejectRevision(ctx, revID, botPrincipal(adminID))
+		}
+		if err == service.ErrInvalidTransition {
+			log.Printf("Revision %s already processed", revIDStr)
+			return
+		}
+		if err != nil {
+			// An approval that failed leaves the revision pending, so the buttons stay
+			b.sendMessage(chatID, "Failed to process edit: "+err.Error())
+			return
+		}
+
+		status := "\n\n❌ EDIT REJECTED"
+		if approve {
+			status = "\n\n✅ EDIT APPLIED"
+		}
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, callback.Message.Text+status)
+		if _, err := b.api.Send(edit); err != nil {
+			log.Printf("Error editing message after revision review: %v", err)
+		}
+
+		notifier := NewAdminNotifier(b.api, b.cfg.AdminTelegramIDs)
+		notifier.NotifyAuthorRevision(post.AuthorTelegramID, approve, post.Title, post.Language)
+		return
+	}
+
+	// Handle retry of a post that failed to publish
+	if strings.HasPrefix(data, "retry_publish:") {
+		jobID, err := uuid.Parse(strings.TrimPrefix(data, "retry_publish:"))
+		if err != nil {
+			b.sendMessage(chatID, "Invalid job ID")
+			return
+		}
+
+		err = b.jobService.RetryPublish(ctx, jobID, botPrincipal(adminID))
+		if err == service.ErrNoFailedPublish {
+			// Another admin already retried it, or it has been published since
+			b.api.Request(tgbotapi.NewCallback(callback.ID, "Nothing to retry"))
+			return
+		}
+		if err != nil {
+			b.sendMessage(chatID, "Failed to retry: "+err.Error())
+			return
 		}
 
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, callback.Message.Text+"\n\n🔁 QUEUED FOR PUBLISHING AGAIN")
+		if _, err := b.api.Send(edit); err != nil {
+			log.Printf("Error editing message after retry: %v", err)
+		}
 		return
 	}
 
@@ -401,7 +836,7 @@ func (b *Bot) handleAdminCallback(callback *tgbotapi.CallbackQuery) {
 		jobInfo, _ := b.jobService.GetJobWithCompany(ctx, jobID)
 
 		log.Printf("Archiving job %s", jobID.String())
-		err = b.jobService.ArchiveJob(ctx, jobID, adminID)
+		err = b.jobService.ArchiveJob(ctx, jobID, botPrincipal(adminID))
 		if err != nil {
 			log.Printf("Failed to archive job: %v", err)
 			b.sendMessage(chatID, "Failed to delete: "+err.Error())
@@ -454,3 +889,46 @@ func (b *Bot) handleAdminCallback(callback *tgbotapi.CallbackQuery) {
 		return
 	}
 }
+
+// rejectWithCustomReason handles the admin's free-text rejection reason
+func (b *Bot) rejectWithCustomReason(chatID int64, adminID int64, postIDStr string, reason string) {
+	b.fsm.SetRejectPostID(adminID, "")
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		b.sendMessage(chatID, "Reason is empty, rejection cancelled")
+		return
+	}
+
+	ctx := context.Background()
+	jobID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		b.sendMessage(chatID, "Invalid job ID")
+		return
+	}
+	jobInfo, err := b.jobService.GetJobWithCompany(ctx, jobID)
+	if err != nil {
+		b.sendMessage(chatID, "Job not found")
+		return
+	}
+
+	if b.rejectPost(ctx, chatID, adminID, jobInfo, reason) {
+		b.sendMessage(chatID, fmt.Sprintf("❌ *%s* rejected.\nReason: %s", escapeMarkdownAdmin(jobInfo.Title), escapeMarkdownAdmin(reason)))
+	}
+}
+
+// rejectPost rejects a pending post with a reason; JobService notifies the
+// author. It reports whether the post was rejected by this call.
+func (b *Bot) rejectPost(ctx context.Context, chatID int64, adminID int64, post *domain.PostWithDetails, reason string) bool {
+	err := b.jobService.RejectJob(ctx, post.ID, botPrincipal(adminID), reason)
+	if err == service.ErrInvalidTransition {
+		log.Printf("Job %s already processed", post.ID)
+		b.sendMessage(chatID, "This post has already been moderated")
+		return false
+	}
+	if err != nil {
+		b.sendMessage(chatID, "Failed to reject: "+err.Error())
+		return false
+	}
+	return true
+}
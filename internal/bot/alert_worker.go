@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+)
+
+// alertSendDelay keeps alerts at ~25 messages per second, under Telegram's broadcast limit
+const alertSendDelay = 40 * time.Millisecond
+
+// AlertWorker forwards published posts to subscribers whose filters match them.
+// Alerts are queued in the database when a post is published.
+type AlertWorker struct {
+	bot      *Bot
+	interval time.Duration
+	batch    int
+}
+
+func NewAlertWorker(bot *Bot) *AlertWorker {
+	return &AlertWorker{
+		bot:      bot,
+		interval: 5 * time.Second,
+		batch:    100,
+	}
+}
+
+func (w *AlertWorker) Start(ctx context.Context) {
+	log.Printf("Alert worker started. Checking every %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Alert worker stopped")
+			return
+		case <-ticker.C:
+			w.sendDue(ctx)
+		}
+	}
+}
+
+func (w *AlertWorker) sendDue(ctx context.Context) {
+	alerts, err := w.bot.subscriptionService.ClaimAlerts(ctx, w.batch)
+	if err != nil {
+		log.Printf("Error claiming job alerts: %v", err)
+		return
+	}
+
+	posts := make(map[uuid.UUID]*domain.Post)
+	for _, alert := range alerts {
+		post, ok := posts[alert.PostID]
+		if !ok {
+			post, _ = w.bot.jobService.GetJob(ctx, alert.PostID)
+			posts[alert.PostID] = post
+		}
+
+		// Closed or deleted since it was published: nothing to forward
+		if post == nil || post.Status != domain.JobStatusPublished || post.ChannelMessageID == nil {
+			w.done(ctx, alert)
+			continue
+		}
+
+		_, err := w.bot.api.Send(tgbotapi.NewForward(alert.TelegramID, w.bot.cfg.ChannelID, *post.ChannelMessageID))
+		var tgErr *tgbotapi.Error
+		switch {
+		case err == nil:
+		case errors.As(err, &tgErr) && tgErr.RetryAfter > 0:
+			// Rate limited: the rest of the batch is retried once the lease runs out
+			log.Printf("Job alerts rate limited: %v", err)
+			return
+		case errors.As(err, &tgErr) && tgErr.Code == http.StatusForbidden:
+			// The user blocked the bot
+			log.Printf("Removing subscriptions of %d: %v", alert.TelegramID, err)
+			if err := w.bot.subscriptionService.UnsubscribeAll(ctx, alert.TelegramID); err != nil {
+				log.Printf("Error removing subscriptions of %d: %v", alert.TelegramID, err)
+			}
+		default:
+			log.Printf("Error sending job alert to %d: %v", alert.TelegramID, err)
+		}
+
+		w.done(ctx, alert)
+		time.Sleep(alertSendDelay)
+	}
+}
+
+func (w *AlertWorker) done(ctx context.Context, alert domain.JobAlert) {
+	if err := w.bot.subscriptionService.AlertDone(ctx, alert.ID); err != nil {
+		log.Printf("Error removing job alert %s: %v", alert.ID, err)
+	}
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+// cmdAPIKeyCreate issues an HTTP API key: /apikey_create <telegram id> <name>
+func (b *Bot) cmdAPIKeyCreate(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	usage := "Usage: /apikey\\_create <telegram id> <name>\nThe key acts as that Telegram user (admin rights included)."
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) < 2 {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	telegramID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+
+	key, apiKey, err := b.authService.CreateAPIKey(context.Background(), msg.From.ID, telegramID, strings.Join(args[1:], " "))
+	if err == service.ErrInvalidAPIKey {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	if err != nil {
+		log.Printf("Error creating API key: %v", err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, fmt.Sprintf(
+		"🔑 API key *%s* created for `%d`\n\n`%s`\n\nSend it as the `X-API-Key` header. It is shown only once — store it now.\nRevoke with /apikey\\_revoke %s",
+		escapeMarkdown(apiKey.Name), apiKey.TelegramID, key, apiKey.ID))
+}
+
+// cmdAPIKeyRevoke revokes an API key: /apikey_revoke <id>
+func (b *Bot) cmdAPIKeyRevoke(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	id, err := uuid.Parse(strings.TrimSpace(msg.CommandArguments()))
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, "Usage: /apikey\\_revoke <key id>")
+		return
+	}
+
+	err = b.authService.RevokeAPIKey(context.Background(), msg.From.ID, id)
+	if err == service.ErrNotFound {
+		b.sendMessage(msg.Chat.ID, "API key not found or already revoked")
+		return
+	}
+	if err != nil {
+		log.Printf("Error revoking API key %s: %v", id, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, "🚫 API key revoked")
+}
+
+// cmdAPIKeys lists issued API keys
+func (b *Bot) cmdAPIKeys(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	keys, err := b.authService.ListAPIKeys(context.Background(), msg.From.ID)
+	if err != nil {
+		log.Printf("Error listing API keys: %v", err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	if len(keys) == 0 {
+		b.sendMessage(msg.Chat.ID, "No API keys yet. Create one with /apikey\\_create")
+		return
+	}
+
+	text := "🔑 *API keys*\n"
+	for _, key := range keys {
+		text += "\n" + formatAPIKey(&key) + "\n"
+	}
+	b.sendMessage(msg.Chat.ID, text)
+}
+
+func formatAPIKey(key *domain.APIKey) string {
+	status := "✅ active"
+	if key.RevokedAt != nil {
+		status = "🚫 revoked " + key.RevokedAt.Format("2006-01-02")
+	}
+	lastUsed := "never"
+	if key.LastUsedAt != nil {
+		lastUsed = key.LastUsedAt.Format("2006-01-02 15:04")
+	}
+	return fmt.Sprintf("*%s* · `%d` · %s\nLast used: %s\n`%s`",
+		escapeMarkdown(key.Name), key.TelegramID, status, lastUsed, key.ID)
+}
@@ -6,6 +6,7 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
 	"telegram-job/internal/repository"
 	"telegram-job/internal/service"
 )
@@ -16,6 +17,14 @@ type Bot struct {
 	jobService *service.JobService
 	userRepo   *repository.UserRepository
 	fsm        *FSM
+
+	paymentService *service.PaymentService
+	packageService *service.PackageService
+	promoService   *service.PromoService
+
+	subscriptionService *service.SubscriptionService
+	digestService       *service.DigestService
+	authService         *service.AuthService
 }
 
 func New(cfg *config.Config, jobService *service.JobService, userRepo *repository.UserRepository) (*Bot, error) {
@@ -43,6 +52,35 @@ func (b *Bot) SetJobService(jobService *service.JobService) {
 	b.jobService = jobService
 }
 
+func (b *Bot) SetPaymentService(paymentService *service.PaymentService) {
+	b.paymentService = paymentService
+}
+
+func (b *Bot) SetPackageService(packageService *service.PackageService) {
+	b.packageService = packageService
+}
+
+func (b *Bot) SetPromoService(promoService *service.PromoService) {
+	b.promoService = promoService
+}
+
+func (b *Bot) SetSubscriptionService(subscriptionService *service.SubscriptionService) {
+	b.subscriptionService = subscriptionService
+}
+
+func (b *Bot) SetAuthService(authService *service.AuthService) {
+	b.authService = authService
+}
+
+func (b *Bot) SetDigestService(digestService *service.DigestService) {
+	b.digestService = digestService
+}
+
+// SetStateStore replaces the FSM storage (in-memory by default)
+func (b *Bot) SetStateStore(store StateStore) {
+	b.fsm = NewFSMWithStore(store)
+}
+
 func (b *Bot) GetAPI() *tgbotapi.BotAPI {
 	return b.api
 }
@@ -54,22 +92,48 @@ func (b *Bot) Start() {
 	updates := b.api.GetUpdatesChan(u)
 
 	for update := range updates {
-		if update.CallbackQuery != nil {
-			b.handleCallback(update.CallbackQuery)
-			continue
-		}
+		b.handleUpdate(update)
+	}
+}
+
+// handleUpdate routes one update to its handler
+func (b *Bot) handleUpdate(update tgbotapi.Update) {
+	if update.CallbackQuery != nil {
+		b.handleCallback(update.CallbackQuery)
+		return
+	}
+
+	if update.InlineQuery != nil {
+		b.handleInlineQuery(update.InlineQuery)
+		return
+	}
 
-		if update.Message == nil {
-			continue
-		}
+	if update.PreCheckoutQuery != nil {
+		b.handlePreCheckout(update.PreCheckoutQuery)
+		return
+	}
+
+	if update.Message == nil {
+		return
+	}
 
-		if update.Message.IsCommand() {
-			b.handleCommand(update.Message)
-			continue
-		}
+	if update.Message.SuccessfulPayment != nil {
+		b.handleSuccessfulPayment(update.Message)
+		return
+	}
 
-		b.handleMessage(update.Message)
+	if update.Message.IsCommand() {
+		b.handleCommand(update.Message)
+		return
 	}
+
+	b.handleMessage(update.Message)
+}
+
+// botPrincipal is the sender of an update. Telegram delivers updates only
+// to the bot, so the sender ID is already verified.
+func botPrincipal(telegramID int64) *domain.Principal {
+	return &domain.Principal{Kind: domain.PrincipalBot, TelegramID: telegramID}
 }
 
 func (b *Bot) sendMessage(chatID int64, text string) {
@@ -2,9 +2,11 @@ package bot
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
+	"telegram-job/internal/domain"
 	"telegram-job/internal/repository"
 	"telegram-job/internal/service"
 )
@@ -62,6 +64,20 @@ func (c *CleanupService) cleanup(ctx context.Context) {
 	log.Printf("Found %d expired jobs to archive", len(jobs))
 
 	for _, job := range jobs {
+		change, err := domain.Transition(&job, domain.JobStatusArchived, domain.ActorSystem)
+		if err != nil {
+			log.Printf("Job %s can't be archived from status %s", job.ID, job.Status)
+			continue
+		}
+
+		// Архивируем в БД
+		err = c.jobRepo.Archive(ctx, job.ID, change, fmt.Sprintf("Expired after %d days", c.maxDays), nil)
+		if err != nil {
+			log.Printf("Error archiving job %s: %v", job.ID, err)
+			continue
+		}
+		log.Printf("Archived job %s: %s", job.ID, job.Title)
+
 		// Удаляем из канала
 		if c.publisher != nil && job.ChannelMessageID != nil {
 			err := c.publisher.Delete(ctx, *job.ChannelMessageID)
@@ -71,14 +87,6 @@ func (c *CleanupService) cleanup(ctx context.Context) {
 				log.Printf("Deleted job %s from channel (message_id: %d)", job.ID, *job.ChannelMessageID)
 			}
 		}
-
-		// Архивируем в БД
-		err := c.jobRepo.Archive(ctx, job.ID)
-		if err != nil {
-			log.Printf("Error archiving job %s: %v", job.ID, err)
-		} else {
-			log.Printf("Archived job %s: %s", job.ID, job.Title)
-		}
 	}
 
 	log.Printf("Cleanup complete. Archived %d jobs", len(jobs))
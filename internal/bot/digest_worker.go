@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/publisher"
+	"telegram-job/internal/service"
+)
+
+// maxDigestPosts keeps a digest well under Telegram's 4096 character limit
+const maxDigestPosts = 40
+
+// DigestWorker sends daily and weekly digests to subscribers once their
+// period is over and posts the weekly channel digest
+type DigestWorker struct {
+	bot      *Bot
+	interval time.Duration
+	batch    int
+}
+
+func NewDigestWorker(bot *Bot) *DigestWorker {
+	return &DigestWorker{
+		bot:      bot,
+		interval: 10 * time.Minute,
+		batch:    100,
+	}
+}
+
+func (w *DigestWorker) Start(ctx context.Context) {
+	log.Printf("Digest worker started. Checking every %s", w.interval)
+
+	w.sendDue(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Digest worker stopped")
+			return
+		case <-ticker.C:
+			w.sendDue(ctx)
+		}
+	}
+}
+
+func (w *DigestWorker) sendDue(ctx context.Context) {
+	posted, err := w.bot.digestService.PublishChannelDigest(ctx)
+	if err != nil {
+		log.Printf("Error posting channel digest: %v", err)
+	} else if posted {
+		log.Println("Posted weekly digest to the channel")
+	}
+
+	for _, frequency := range []domain.AlertFrequency{domain.AlertDaily, domain.AlertWeekly} {
+		for ctx.Err() == nil {
+			digests, err := w.bot.digestService.ClaimDigests(ctx, frequency, w.batch)
+			if err != nil {
+				log.Printf("Error claiming %s digests: %v", frequency, err)
+				break
+			}
+
+			for _, digest := range digests {
+				if len(digest.Posts) > 0 {
+					w.send(ctx, digest)
+				}
+			}
+
+			if len(digests) < w.batch {
+				break
+			}
+		}
+	}
+}
+
+func (w *DigestWorker) send(ctx context.Context, digest service.Digest) {
+	m := w.bot.getInterfaceMessages(digest.TelegramID)
+	msg := tgbotapi.NewMessage(digest.TelegramID, formatDigest(digest, m, w.bot.cfg.ChannelPostLink))
+	msg.ParseMode = "Markdown"
+	msg.DisableWebPagePreview = true
+
+	_, err := w.bot.api.Send(msg)
+
+	// Digests are claimed before sending, so wait out a rate limit and retry once
+	var tgErr *tgbotapi.Error
+	if errors.As(err, &tgErr) && tgErr.RetryAfter > 0 {
+		time.Sleep(time.Duration(tgErr.RetryAfter) * time.Second)
+		_, err = w.bot.api.Send(msg)
+	}
+
+	switch {
+	case err == nil:
+	case errors.As(err, &tgErr) && tgErr.Code == http.StatusForbidden:
+		// The user blocked the bot
+		log.Printf("Removing subscriptions of %d: %v", digest.TelegramID, err)
+		if err := w.bot.subscriptionService.UnsubscribeAll(ctx, digest.TelegramID); err != nil {
+			log.Printf("Error removing subscriptions of %d: %v", digest.TelegramID, err)
+		}
+	default:
+		log.Printf("Error sending %s digest to %d: %v", digest.Frequency, digest.TelegramID, err)
+	}
+
+	time.Sleep(alertSendDelay)
+}
+
+func formatDigest(digest service.Digest, m Messages, postLink func(messageID int) string) string {
+	title := m.DigestDailyTitle
+	if digest.Frequency == domain.AlertWeekly {
+		title = m.DigestWeeklyTitle
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, title, len(digest.Posts))
+	sb.WriteString("\n")
+
+	for i, post := range digest.Posts {
+		if i == maxDigestPosts {
+			sb.WriteString("\n" + fmt.Sprintf(m.DigestMore, len(digest.Posts)-maxDigestPosts))
+			break
+		}
+
+		sb.WriteString("\n" + publisher.FormatPostLine(&post, postLink(*post.ChannelMessageID), m.SalaryFromLabel))
+	}
+
+	sb.WriteString("\n\n" + m.DigestFooter)
+	return sb.String()
+}
@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// DraftReminderService expires abandoned drafts and sends a single
+// "continue or discard" reminder to users whose draft has been idle for a while
+type DraftReminderService struct {
+	bot         *Bot
+	interval    time.Duration
+	ttl         time.Duration
+	remindAfter time.Duration
+}
+
+func NewDraftReminderService(bot *Bot, ttlHours int, remindHours int) *DraftReminderService {
+	return &DraftReminderService{
+		bot:         bot,
+		interval:    15 * time.Minute,
+		ttl:         time.Duration(ttlHours) * time.Hour,
+		remindAfter: time.Duration(remindHours) * time.Hour,
+	}
+}
+
+func (s *DraftReminderService) Start(ctx context.Context) {
+	log.Printf("Draft reminder service started. Remind after %s, expire after %s", s.remindAfter, s.ttl)
+
+	s.check()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Draft reminder service stopped")
+			return
+		case <-ticker.C:
+			s.check()
+		}
+	}
+}
+
+func (s *DraftReminderService) check() {
+	now := time.Now().UTC()
+	expireBefore := now.Add(-s.ttl)
+	remindBefore := now.Add(-s.remindAfter)
+
+	// One query covers both windows, whichever is shorter
+	idleSince := remindBefore
+	if expireBefore.After(idleSince) {
+		idleSince = expireBefore
+	}
+	idle, err := s.bot.fsm.ListIdle(idleSince)
+	if err != nil {
+		log.Printf("Error listing idle drafts: %v", err)
+		return
+	}
+
+	expired, reminded := 0, 0
+	for userID, state := range idle {
+		if !state.hasDraft() {
+			continue
+		}
+
+		// Expire drafts idle longer than TTL, unless the user came back since
+		if state.UpdatedAt.Before(expireBefore) {
+			ok, err := s.bot.fsm.ExpireDraft(userID, expireBefore)
+			if err != nil {
+				log.Printf("Error expiring draft of user %d: %v", userID, err)
+			} else if ok {
+				expired++
+			}
+			continue
+		}
+
+		// Nothing worth resuming yet
+		if !state.UpdatedAt.Before(remindBefore) || state.State == StateNone || state.State == StateWaitPostType || state.RemindedAt != nil {
+			continue
+		}
+
+		ok, err := s.bot.fsm.MarkReminded(userID, remindBefore)
+		if err != nil {
+			log.Printf("Error marking draft reminder for user %d: %v", userID, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		s.sendReminder(userID, state)
+		reminded++
+	}
+
+	if expired > 0 || reminded > 0 {
+		log.Printf("Draft check complete. Expired %d, reminded %d", expired, reminded)
+	}
+}
+
+func (s *DraftReminderService) sendReminder(userID int64, state *UserState) {
+	m := GetMessages(state.Language)
+	remaining := int(s.ttl.Hours() - time.Since(state.UpdatedAt).Hours())
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Continue, "draft:continue"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Discard, "draft:discard"),
+		),
+	)
+	s.bot.sendMessageWithKeyboard(userID, fmt.Sprintf(m.DraftReminder, remaining), keyboard)
+}
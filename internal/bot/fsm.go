@@ -1,17 +1,21 @@
 package bot
 
 import (
-	"sync"
+	"context"
+	"log"
+	"time"
 
 	"telegram-job/internal/domain"
 )
 
+// State values are persisted by the state store, so new states must be
+// appended at the end of the list to keep stored values stable.
 type State int
 
 const (
-	StateNone State = iota
-	StateWaitPostType    // NEW: Choose vacancy or resume
-	StateWaitLanguage    // Choose post language
+	StateNone         State = iota
+	StateWaitPostType       // NEW: Choose vacancy or resume
+	StateWaitLanguage       // Choose post language
 
 	// Vacancy states
 	StateWaitCompany
@@ -38,8 +42,74 @@ const (
 	StateResumeWaitContact
 	StateResumeWaitLink
 	StateResumePreview
+
+	// Entered from the vacancy preview
+	StateWaitPromoCode
 )
 
+// Step order of each flow, used for Back/Keep navigation
+var (
+	vacancySteps = []State{
+		StateWaitCompany,
+		StateWaitContact,
+		StateWaitTitle,
+		StateWaitLevel,
+		StateWaitType,
+		StateWaitCategory,
+		StateWaitDescription,
+		StateWaitSalaryFrom,
+		StateWaitSalaryTo,
+		StateWaitApplyLink,
+		StatePreview,
+	}
+	resumeSteps = []State{
+		StateResumeWaitTitle,
+		StateResumeWaitLevel,
+		StateResumeWaitExperience,
+		StateResumeWaitType,
+		StateResumeWaitEmployment,
+		StateResumeWaitSalaryFrom,
+		StateResumeWaitSalaryTo,
+		StateResumeWaitAbout,
+		StateResumeWaitContact,
+		StateResumeWaitLink,
+		StateResumePreview,
+	}
+)
+
+func flowSteps(postType domain.PostType) []State {
+	if postType == domain.PostTypeResume {
+		return resumeSteps
+	}
+	return vacancySteps
+}
+
+// PreviousState returns the step before state in the flow for postType.
+// The first step goes back to the post type selection.
+func PreviousState(postType domain.PostType, state State) (State, bool) {
+	steps := flowSteps(postType)
+	for i, s := range steps {
+		if s == state {
+			if i == 0 {
+				return StateWaitPostType, true
+			}
+			return steps[i-1], true
+		}
+	}
+	return StateNone, false
+}
+
+// NextState returns the step after state in the flow for postType
+func NextState(postType domain.PostType, state State) (State, bool) {
+	steps := flowSteps(postType)
+	for i, s := range steps {
+		if s == state && i+1 < len(steps) {
+			return steps[i+1], true
+		}
+	}
+	return StateNone, false
+}
+
 type Language string
 
 const (
@@ -49,27 +119,29 @@ const (
 
 // PostDraft holds data for both vacancy and resume
 type PostDraft struct {
-	PostType domain.PostType
+	PostType domain.PostType `json:"post_type"`
+	PostID   string          `json:"post_id,omitempty"` // Set when editing an existing post
 
 	// Vacancy fields
-	Company     string
-	Contact     string // Author contact (for admins)
-	Title       string
-	Level       domain.JobLevel
-	Type        domain.JobType
-	Category    domain.JobCategory
-	SalaryFrom  *int
-	SalaryTo    *int
-	Description string
-	ApplyLink   string // For candidates
-	Language    string
+	Company     string             `json:"company,omitempty"`
+	Contact     string             `json:"contact,omitempty"` // Author contact (for admins)
+	Title       string             `json:"title,omitempty"`
+	Level       domain.JobLevel    `json:"level,omitempty"`
+	Type        domain.JobType     `json:"type,omitempty"`
+	Category    domain.JobCategory `json:"category,omitempty"`
+	SalaryFrom  *int               `json:"salary_from,omitempty"`
+	SalaryTo    *int               `json:"salary_to,omitempty"`
+	Description string             `json:"description,omitempty"`
+	ApplyLink   string             `json:"apply_link,omitempty"` // For candidates
+	Language    string             `json:"language,omitempty"`
+	PromoCode   string             `json:"promo_code,omitempty"`
 
 	// Resume fields
-	ExperienceYears *float64
-	Employment      domain.EmploymentType
-	About           string
-	ResumeLink      string
-	ResumeContact   string // Candidate contact
+	ExperienceYears *float64              `json:"experience_years,omitempty"`
+	Employment      domain.EmploymentType `json:"employment,omitempty"`
+	About           string                `json:"about,omitempty"`
+	ResumeLink      string                `json:"resume_link,omitempty"`
+	ResumeContact   string                `json:"resume_contact,omitempty"` // Candidate contact
 }
 
 // JobDraft is alias for backward compatibility
@@ -79,104 +151,172 @@ type UserState struct {
 	State    State
 	Language Language
 	Draft    PostDraft
+	Editing  bool // Editing a single field from the preview
+
+	RejectPostID string // Admin: post waiting for a free-text rejection reason
+
+	Search *SearchSession // Last /search, refined by its filter and page buttons
+
+	CreatedAt  time.Time  // When the draft was started
+	UpdatedAt  time.Time  // Last user activity
+	RemindedAt *time.Time // When the idle reminder was sent (reset on activity)
 }
 
+// hasDraft reports whether the user is in the middle of a submission
+func (s *UserState) hasDraft() bool {
+	return s.State != StateNone || s.Draft != (PostDraft{})
+}
+
+// FSM keeps per-user conversation state in a StateStore. Every change is an
+// atomic read-modify-write in the store, so the bot can be restarted (or run
+// as several replicas) without losing half-filled drafts when a persistent
+// store is used.
 type FSM struct {
-	mu     sync.RWMutex
-	states map[int64]*UserState
+	store StateStore
 }
 
 func NewFSM() *FSM {
-	return &FSM{
-		states: make(map[int64]*UserState),
+	return NewFSMWithStore(NewMemoryStateStore())
+}
+
+func NewFSMWithStore(store StateStore) *FSM {
+	return &FSM{store: store}
+}
+
+// load returns the stored state for a user or nil if there is none. Read
+// errors are logged; getters fall back to defaults, while update reads the
+// state itself inside the store and never saves over it after a failed read.
+func (f *FSM) load(userID int64) (*UserState, error) {
+	state, err := f.store.Load(context.Background(), userID)
+	if err != nil {
+		log.Printf("Error loading FSM state for user %d: %v", userID, err)
+		return nil, err
 	}
+	return state, nil
 }
 
-func (f *FSM) GetState(userID int64) *UserState {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
+// update applies fn to the user's state (creating it if needed) and saves
+// it. If the state can't be read or saved, nothing is changed.
+func (f *FSM) update(userID int64, fn func(*UserState)) {
+	now := time.Now().UTC()
+	err := f.store.Update(context.Background(), userID, func(state *UserState) {
+		if state.CreatedAt.IsZero() {
+			state.CreatedAt = now
+		}
+		fn(state)
+		state.UpdatedAt = now
+		state.RemindedAt = nil
+	})
+	if err != nil {
+		log.Printf("Error updating FSM state for user %d: %v", userID, err)
+	}
+}
 
-	state, ok := f.states[userID]
-	if !ok {
+func (f *FSM) GetState(userID int64) *UserState {
+	state, err := f.load(userID)
+	if err != nil || state == nil {
 		return &UserState{State: StateNone}
 	}
 	return state
 }
 
 func (f *FSM) SetState(userID int64, state State) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	if _, ok := f.states[userID]; !ok {
-		f.states[userID] = &UserState{}
-	}
-	f.states[userID].State = state
+	f.update(userID, func(s *UserState) { s.State = state })
 }
 
 func (f *FSM) SetLanguage(userID int64, lang Language) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	if _, ok := f.states[userID]; !ok {
-		f.states[userID] = &UserState{}
-	}
-	f.states[userID].Language = lang
+	f.update(userID, func(s *UserState) { s.Language = lang })
 }
 
 func (f *FSM) GetLanguage(userID int64) Language {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
-	if state, ok := f.states[userID]; ok {
+	if state, err := f.load(userID); err == nil && state != nil {
 		return state.Language
 	}
 	return LangEN // default to English
 }
 
 func (f *FSM) SetPostType(userID int64, postType domain.PostType) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	if _, ok := f.states[userID]; !ok {
-		f.states[userID] = &UserState{}
-	}
-	f.states[userID].Draft.PostType = postType
+	f.update(userID, func(s *UserState) { s.Draft.PostType = postType })
 }
 
 func (f *FSM) GetPostType(userID int64) domain.PostType {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
-	if state, ok := f.states[userID]; ok {
+	if state, err := f.load(userID); err == nil && state != nil {
 		return state.Draft.PostType
 	}
 	return domain.PostTypeVacancy // default
 }
 
 func (f *FSM) UpdateDraft(userID int64, updater func(*PostDraft)) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	if _, ok := f.states[userID]; !ok {
-		f.states[userID] = &UserState{}
-	}
-	updater(&f.states[userID].Draft)
+	f.update(userID, func(s *UserState) { updater(&s.Draft) })
 }
 
 func (f *FSM) GetDraft(userID int64) *PostDraft {
-	f.mu.RLock()
-	defer f.mu.RUnlock()
-
-	if state, ok := f.states[userID]; ok {
+	if state, err := f.load(userID); err == nil && state != nil {
 		return &state.Draft
 	}
 	return nil
 }
 
+// SetSearch remembers the user's last /search. Searching doesn't touch the
+// draft, so its idle timers are left as they are.
+func (f *FSM) SetSearch(userID int64, search *SearchSession) {
+	err := f.store.Update(context.Background(), userID, func(s *UserState) { s.Search = search })
+	if err != nil {
+		log.Printf("Error saving search of user %d: %v", userID, err)
+	}
+}
+
+// SetRejectPostID remembers (or clears, with "") the post an admin is
+// writing a rejection reason for
+func (f *FSM) SetRejectPostID(userID int64, postID string) {
+	f.update(userID, func(s *UserState) { s.RejectPostID = postID })
+}
+
 func (f *FSM) Reset(userID int64) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	delete(f.states, userID)
+	if err := f.store.Delete(context.Background(), userID); err != nil {
+		log.Printf("Error deleting FSM state for user %d: %v", userID, err)
+	}
+}
+
+// ListIdle returns states of users with a draft who have been inactive since idleSince
+func (f *FSM) ListIdle(idleSince time.Time) (map[int64]*UserState, error) {
+	return f.store.ListIdle(context.Background(), idleSince)
+}
+
+// ExpireDraft drops the draft of a user inactive since idleSince, keeping
+// their language and admin state. It returns false if they were active since.
+func (f *FSM) ExpireDraft(userID int64, idleSince time.Time) (bool, error) {
+	return f.store.ExpireDraft(context.Background(), userID, idleSince)
+}
+
+// MarkReminded records that the idle reminder was sent. It returns false if
+// another worker has already reminded this user or they were active since idleSince.
+func (f *FSM) MarkReminded(userID int64, idleSince time.Time) (bool, error) {
+	return f.store.MarkReminded(context.Background(), userID, idleSince, time.Now().UTC())
+}
+
+// draftFromPost opens an existing post as a draft for editing
+func draftFromPost(post *domain.PostWithDetails) PostDraft {
+	return PostDraft{
+		PostType:        post.PostType,
+		PostID:          post.ID.String(),
+		Company:         post.CompanyName,
+		Contact:         post.CompanyContact,
+		Title:           post.Title,
+		Level:           post.Level,
+		Type:            post.Type,
+		Category:        post.Category,
+		SalaryFrom:      post.SalaryFrom,
+		SalaryTo:        post.SalaryTo,
+		Description:     post.Description,
+		ApplyLink:       post.ApplyLink,
+		Language:        post.Language,
+		ExperienceYears: post.ExperienceYears,
+		Employment:      post.Employment,
+		About:           post.About,
+		ResumeLink:      post.ResumeLink,
+		ResumeContact:   post.Contact,
+	}
 }
 
 func (d *PostDraft) ToCreateJobRequest() *domain.CreateJobRequest {
@@ -192,6 +332,7 @@ func (d *PostDraft) ToCreateJobRequest() *domain.CreateJobRequest {
 		Description: d.Description,
 		ApplyLink:   d.ApplyLink,
 		Language:    d.Language,
+		PromoCode:   d.PromoCode,
 	}
 }
 
@@ -2,13 +2,16 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
 	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
 )
 
 func escapeMarkdown(s string) string {
@@ -32,6 +35,14 @@ func (b *Bot) handleCommand(msg *tgbotapi.Message) {
 		b.cmdPostJob(msg)
 	case "myjobs":
 		b.cmdMyJobs(msg)
+	case "balance":
+		b.cmdBalance(msg)
+	case "search":
+		b.cmdSearch(msg)
+	case "subscribe":
+		b.cmdSubscribe(msg)
+	case "subscriptions":
+		b.cmdSubscriptions(msg)
 	case "pricing", "prices":
 		b.cmdPrices(msg)
 	case "faq":
@@ -51,6 +62,28 @@ func (b *Bot) handleCommand(msg *tgbotapi.Message) {
 		b.cmdStats(msg)
 	case "admins":
 		b.cmdAdmins(msg)
+	case "history":
+		b.cmdHistory(msg)
+	case "payment":
+		b.cmdPayment(msg)
+	case "payments":
+		b.cmdPayments(msg)
+	case "grant":
+		b.cmdGrant(msg)
+	case "adjust":
+		b.cmdAdjust(msg)
+	case "promo_create":
+		b.cmdPromoCreate(msg)
+	case "promo_disable":
+		b.cmdPromoDisable(msg)
+	case "apikey_create":
+		b.cmdAPIKeyCreate(msg)
+	case "apikey_revoke":
+		b.cmdAPIKeyRevoke(msg)
+	case "apikeys":
+		b.cmdAPIKeys(msg)
+	case "promos":
+		b.cmdPromos(msg)
 	default:
 		m := b.getInterfaceMessages(msg.From.ID)
 		b.sendMessage(msg.Chat.ID, m.UnknownCommand)
@@ -71,14 +104,7 @@ func (b *Bot) cmdPostJob(msg *tgbotapi.Message) {
 	m := b.getInterfaceMessages(msg.From.ID)
 	b.fsm.Reset(msg.From.ID)
 	b.fsm.SetState(msg.From.ID, StateWaitPostType)
-
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Vacancy, "post_type:vacancy"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Resume, "post_type:resume"),
-		),
-	)
-	b.sendMessageWithKeyboard(msg.Chat.ID, m.ChoosePostType, keyboard)
+	b.sendPostTypeKeyboard(msg.Chat.ID, m)
 }
 
 func (b *Bot) cmdCancel(msg *tgbotapi.Message) {
@@ -152,6 +178,7 @@ func (b *Bot) cmdMyJobs(msg *tgbotapi.Message) {
 	}
 
 	text := m.YourPosts + "\n"
+	var rows [][]tgbotapi.InlineKeyboardButton
 	for i, post := range posts {
 		statusEmoji := getStatusEmoji(post.Status)
 		statusText := getStatusText(post.Status, lang)
@@ -160,9 +187,102 @@ func (b *Bot) cmdMyJobs(msg *tgbotapi.Message) {
 			postTypeEmoji = "👤"
 		}
 		text += fmt.Sprintf("\n%d. %s *%s*\n   %s %s\n", i+1, postTypeEmoji, escapeMarkdown(post.Title), statusEmoji, statusText)
+
+		// Edit / close actions for posts that are still active
+		if isEditableStatus(post.Status) {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.EditPost, i+1), "edit_post:"+post.ID.String()),
+				tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.ClosePost, i+1), "close_post:"+post.ID.String()),
+			))
+		}
 	}
 
-	b.sendMessage(msg.Chat.ID, text)
+	if len(rows) == 0 {
+		b.sendMessage(msg.Chat.ID, text)
+		return
+	}
+	b.sendMessageWithKeyboard(msg.Chat.ID, text, tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+// isEditableStatus reports whether the author may still change a post
+func isEditableStatus(status domain.JobStatus) bool {
+	return status == domain.JobStatusPending || status == domain.JobStatusPublished
+}
+
+// closeReasons are the preset reasons an author can give when closing a post
+var closeReasons = map[string]string{
+	"filled":     "Position filled",
+	"irrelevant": "No longer relevant",
+	"other":      "Other",
+}
+
+func (b *Bot) askCloseReason(chatID int64, userID int64, postIDStr string) {
+	m := b.getInterfaceMessages(userID)
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.CloseFilled, "close_reason:"+postIDStr+":filled"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.CloseIrrelevant, "close_reason:"+postIDStr+":irrelevant"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.CloseOther, "close_reason:"+postIDStr+":other"),
+		),
+	)
+	b.sendMessageWithKeyboard(chatID, m.ChooseCloseReason, keyboard)
+}
+
+// closePost handles "close_reason:<post id>:<reason code>"
+func (b *Bot) closePost(chatID int64, userID int64, data string) {
+	m := b.getInterfaceMessages(userID)
+
+	parts := strings.SplitN(data, ":", 2)
+	if len(parts) != 2 {
+		return
+	}
+	postID, err := uuid.Parse(parts[0])
+	reason, ok := closeReasons[parts[1]]
+	if err != nil || !ok {
+		b.sendMessage(chatID, m.CannotClosePost)
+		return
+	}
+
+	post, err := b.jobService.CloseJob(context.Background(), botPrincipal(userID), postID, reason)
+	if err != nil {
+		log.Printf("Error closing post %s: %v", postID, err)
+		b.sendMessage(chatID, m.CannotClosePost)
+		return
+	}
+
+	b.sendMessage(chatID, fmt.Sprintf(m.PostClosed, escapeMarkdown(post.Title)))
+}
+
+// startPostEdit opens one of the user's posts as a draft and shows its preview
+func (b *Bot) startPostEdit(chatID int64, userID int64, postIDStr string) {
+	m := b.getInterfaceMessages(userID)
+
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		b.sendMessage(chatID, m.CannotEditPost)
+		return
+	}
+
+	post, err := b.jobService.GetJobWithCompany(context.Background(), postID)
+	if err != nil || post.AuthorTelegramID != userID || !isEditableStatus(post.Status) {
+		b.sendMessage(chatID, m.CannotEditPost)
+		return
+	}
+
+	lang := b.getUserInterfaceLanguage(userID)
+	if lang == "" {
+		lang = LangEN
+	}
+
+	b.fsm.Reset(userID)
+	b.fsm.update(userID, func(s *UserState) {
+		s.Language = lang
+		s.Draft = draftFromPost(post)
+		s.State = previewState(post.PostType)
+	})
+	b.sendStatePrompt(chatID, userID)
 }
 
 // Admin commands
@@ -196,33 +316,57 @@ func (b *Bot) cmdPending(msg *tgbotapi.Message) {
 }
 
 func (b *Bot) sendPendingPostToAdmin(chatID int64, post *domain.PostWithDetails) {
-	text := formatAdminNotification(post)
+	msg := tgbotapi.NewMessage(chatID, formatAdminNotification(post))
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = moderationKeyboard(post)
+	b.api.Send(msg)
+}
 
-	var keyboardRows [][]tgbotapi.InlineKeyboardButton
+// cmdHistory shows the moderation log of a post: /history <post id>
+func (b *Bot) cmdHistory(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
 
-	// Contact button
-	contact := post.CompanyContact
-	if contact == "" {
-		contact = post.Contact
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
 	}
-	if strings.HasPrefix(contact, "@") {
-		keyboardRows = append(keyboardRows, tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonURL("📞 Связаться с автором", "https://t.me/"+strings.TrimPrefix(contact, "@")),
-		))
+
+	postID, err := uuid.Parse(strings.TrimSpace(msg.CommandArguments()))
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, "Usage: /history <post id>")
+		return
 	}
 
-	// Approve/Reject buttons
-	keyboardRows = append(keyboardRows, tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("✅ Approve", "approve:"+post.ID.String()),
-		tgbotapi.NewInlineKeyboardButtonData("❌ Reject", "reject:"+post.ID.String()),
-	))
+	events, err := b.jobService.GetHistory(context.Background(), postID, botPrincipal(msg.From.ID))
+	if err == service.ErrNotFound {
+		b.sendMessage(msg.Chat.ID, "Post not found")
+		return
+	}
+	if err != nil {
+		log.Printf("Error getting history of post %s: %v", postID, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
 
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(keyboardRows...)
+	if len(events) == 0 {
+		b.sendMessage(msg.Chat.ID, "No status changes recorded for this post")
+		return
+	}
 
-	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = "Markdown"
-	msg.ReplyMarkup = keyboard
-	b.api.Send(msg)
+	text := fmt.Sprintf("📜 *History of* `%s`\n", postID)
+	for _, event := range events {
+		actor := "system"
+		if event.ActorTelegramID != nil {
+			actor = fmt.Sprintf("`%d`", *event.ActorTelegramID)
+		}
+		text += fmt.Sprintf("\n%s — %s → %s by %s",
+			event.CreatedAt.Format("2006-01-02 15:04"), event.FromStatus, event.ToStatus, actor)
+		if event.Reason != "" {
+			text += "\n   " + escapeMarkdown(event.Reason)
+		}
+	}
+
+	b.sendMessage(msg.Chat.ID, text)
 }
 
 func (b *Bot) cmdStats(msg *tgbotapi.Message) {
@@ -345,289 +489,449 @@ func (b *Bot) handleMessage(msg *tgbotapi.Message) {
 	lang := b.fsm.GetLanguage(msg.From.ID)
 	m := GetMessages(lang)
 	postType := b.fsm.GetPostType(msg.From.ID)
+	chatID := msg.Chat.ID
+	userID := msg.From.ID
+
+	// Admin is typing a rejection reason
+	if userState.RejectPostID != "" && b.cfg.IsAdmin(userID) {
+		b.rejectWithCustomReason(chatID, userID, userState.RejectPostID, msg.Text)
+		return
+	}
+
+	if isBack(msg.Text) && userState.State != StateNone {
+		b.goBack(chatID, userID)
+		return
+	}
 
 	switch userState.State {
 	case StateNone:
-		b.sendMessage(msg.Chat.ID, "Use /post\\_job to submit.\nИспользуйте /post\\_job чтобы добавить публикацию.")
+		b.sendMessage(chatID, "Use /post\\_job to submit.\nИспользуйте /post\\_job чтобы добавить публикацию.")
 		return
 
 	case StateWaitPostType:
-		b.sendMessage(msg.Chat.ID, "Please select using buttons above.")
+		b.sendMessage(chatID, "Please select using buttons above.")
 		return
 
 	// ==================== VACANCY STATES ====================
 	case StateWaitCompany:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Company = msg.Text })
-		b.fsm.SetState(msg.From.ID, StateWaitContact)
-		b.sendMessage(msg.Chat.ID, m.VacStep2Contact)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Company = msg.Text })
+		b.advance(chatID, userID, StateWaitContact)
 
 	case StateWaitContact:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Contact = msg.Text })
-		b.fsm.SetState(msg.From.ID, StateWaitTitle)
-		b.sendMessage(msg.Chat.ID, m.VacStep3Title)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Contact = msg.Text })
+		b.advance(chatID, userID, StateWaitTitle)
 
 	case StateWaitTitle:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Title = msg.Text })
-		b.fsm.SetState(msg.From.ID, StateWaitLevel)
-		b.sendLevelKeyboard(msg.Chat.ID, lang, m.VacStep4Level)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Title = msg.Text })
+		b.advance(chatID, userID, StateWaitLevel)
 
 	case StateWaitLevel:
 		if isSkip(msg.Text) {
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Level = domain.JobLevelSkip })
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Level = domain.JobLevelSkip })
 		} else {
 			level := domain.JobLevel(strings.ToLower(msg.Text))
-			if !isValidLevel(level) {
-				b.sendMessage(msg.Chat.ID, "Select using buttons / Выберите кнопками")
+			if !level.Valid() {
+				b.sendMessage(chatID, "Select using buttons / Выберите кнопками")
 				return
 			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Level = level })
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Level = level })
 		}
-		b.fsm.SetState(msg.From.ID, StateWaitType)
-		b.sendTypeKeyboard(msg.Chat.ID, lang, m.VacStep5Type)
+		b.advance(chatID, userID, StateWaitType)
 
 	case StateWaitType:
 		jobType := domain.JobType(strings.ToLower(msg.Text))
-		if !isValidType(jobType) {
-			b.sendMessage(msg.Chat.ID, "Select using buttons / Выберите кнопками")
+		if !jobType.Valid() {
+			b.sendMessage(chatID, "Select using buttons / Выберите кнопками")
 			return
 		}
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Type = jobType })
-		b.fsm.SetState(msg.From.ID, StateWaitCategory)
-		b.sendCategoryKeyboard(msg.Chat.ID, lang)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Type = jobType })
+		b.advance(chatID, userID, StateWaitCategory)
 
 	case StateWaitCategory:
 		category := domain.JobCategory(strings.ToLower(msg.Text))
-		if !isValidCategory(category) {
-			b.sendMessage(msg.Chat.ID, "Select using buttons / Выберите кнопками")
+		if !category.Valid() {
+			b.sendMessage(chatID, "Select using buttons / Выберите кнопками")
 			return
 		}
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Category = category })
-		b.fsm.SetState(msg.From.ID, StateWaitDescription)
-		b.sendMessage(msg.Chat.ID, m.VacStep7Description)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Category = category })
+		b.advance(chatID, userID, StateWaitDescription)
 
 	case StateWaitDescription:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Description = msg.Text })
-		b.fsm.SetState(msg.From.ID, StateWaitSalaryFrom)
-		b.sendMessage(msg.Chat.ID, m.VacStep8SalaryFrom)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Description = msg.Text })
+		b.advance(chatID, userID, StateWaitSalaryFrom)
 
 	case StateWaitSalaryFrom:
-		if !isSkip(msg.Text) {
-			salary, err := strconv.Atoi(msg.Text)
-			if err != nil {
-				b.sendMessage(msg.Chat.ID, m.InvalidNumber)
-				return
-			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.SalaryFrom = &salary })
+		if !b.acceptSalaryFrom(chatID, userID, msg.Text, m) {
+			return
 		}
-		b.fsm.SetState(msg.From.ID, StateWaitSalaryTo)
-		b.sendMessage(msg.Chat.ID, m.VacStep9SalaryTo)
+		b.advance(chatID, userID, StateWaitSalaryTo)
 
 	case StateWaitSalaryTo:
-		if !isSkip(msg.Text) {
-			salary, err := strconv.Atoi(msg.Text)
-			if err != nil {
-				b.sendMessage(msg.Chat.ID, m.InvalidNumber)
-				return
-			}
-			draft := b.fsm.GetDraft(msg.From.ID)
-			if draft != nil && draft.SalaryFrom != nil && salary < *draft.SalaryFrom {
-				b.sendMessage(msg.Chat.ID, m.SalaryToLessThanFrom)
-				return
-			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.SalaryTo = &salary })
+		if !b.acceptSalaryTo(chatID, userID, msg.Text, m) {
+			return
 		}
-		b.fsm.SetState(msg.From.ID, StateWaitApplyLink)
-		b.sendMessage(msg.Chat.ID, m.VacStep10ApplyLink)
+		b.advance(chatID, userID, StateWaitApplyLink)
 
 	case StateWaitApplyLink:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.ApplyLink = msg.Text })
-		b.fsm.SetState(msg.From.ID, StatePreview)
-		b.sendVacancyPreview(msg.Chat.ID, msg.From.ID)
+		text := strings.TrimSpace(msg.Text)
+		if !domain.IsLink(text) {
+			b.sendMessage(chatID, m.InvalidLink)
+			return
+		}
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.ApplyLink = text })
+		b.advance(chatID, userID, StatePreview)
 
 	// ==================== RESUME STATES ====================
 	case StateResumeWaitTitle:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Title = msg.Text })
-		b.fsm.SetState(msg.From.ID, StateResumeWaitLevel)
-		b.sendLevelKeyboard(msg.Chat.ID, lang, m.ResStep2Level)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Title = msg.Text })
+		b.advance(chatID, userID, StateResumeWaitLevel)
 
 	case StateResumeWaitLevel:
 		if isSkip(msg.Text) {
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Level = domain.JobLevelSkip })
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Level = domain.JobLevelSkip })
 		} else {
 			level := domain.JobLevel(strings.ToLower(msg.Text))
-			if !isValidLevel(level) {
-				b.sendMessage(msg.Chat.ID, "Select using buttons / Выберите кнопками")
+			if !level.Valid() {
+				b.sendMessage(chatID, "Select using buttons / Выберите кнопками")
 				return
 			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Level = level })
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Level = level })
 		}
-		b.fsm.SetState(msg.From.ID, StateResumeWaitExperience)
-		b.sendMessage(msg.Chat.ID, m.ResStep3Experience)
+		b.advance(chatID, userID, StateResumeWaitExperience)
 
 	case StateResumeWaitExperience:
-		if !isSkip(msg.Text) {
+		if isSkip(msg.Text) {
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.ExperienceYears = nil })
+		} else {
 			exp, err := strconv.ParseFloat(msg.Text, 64)
-			if err != nil {
-				b.sendMessage(msg.Chat.ID, m.InvalidExperience)
+			if err != nil || !domain.ValidExperience(exp) {
+				b.sendMessage(chatID, m.InvalidExperience)
 				return
 			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.ExperienceYears = &exp })
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.ExperienceYears = &exp })
 		}
-		b.fsm.SetState(msg.From.ID, StateResumeWaitType)
-		b.sendTypeKeyboard(msg.Chat.ID, lang, m.ResStep4Type)
+		b.advance(chatID, userID, StateResumeWaitType)
 
 	case StateResumeWaitType:
 		jobType := domain.JobType(strings.ToLower(msg.Text))
-		if !isValidType(jobType) {
-			b.sendMessage(msg.Chat.ID, "Select using buttons / Выберите кнопками")
+		if !jobType.Valid() {
+			b.sendMessage(chatID, "Select using buttons / Выберите кнопками")
 			return
 		}
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Type = jobType })
-		b.fsm.SetState(msg.From.ID, StateResumeWaitEmployment)
-		b.sendEmploymentKeyboard(msg.Chat.ID, lang)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Type = jobType })
+		b.advance(chatID, userID, StateResumeWaitEmployment)
 
 	case StateResumeWaitEmployment:
 		emp := domain.EmploymentType(strings.ToLower(msg.Text))
-		if !isValidEmployment(emp) {
-			b.sendMessage(msg.Chat.ID, "Select using buttons / Выберите кнопками")
+		if !emp.Valid() {
+			b.sendMessage(chatID, "Select using buttons / Выберите кнопками")
 			return
 		}
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.Employment = emp })
-		b.fsm.SetState(msg.From.ID, StateResumeWaitSalaryFrom)
-		b.sendMessage(msg.Chat.ID, m.ResStep6SalaryFrom)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Employment = emp })
+		b.advance(chatID, userID, StateResumeWaitSalaryFrom)
 
 	case StateResumeWaitSalaryFrom:
-		if !isSkip(msg.Text) {
-			salary, err := strconv.Atoi(msg.Text)
-			if err != nil {
-				b.sendMessage(msg.Chat.ID, m.InvalidNumber)
-				return
-			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.SalaryFrom = &salary })
+		if !b.acceptSalaryFrom(chatID, userID, msg.Text, m) {
+			return
 		}
-		b.fsm.SetState(msg.From.ID, StateResumeWaitSalaryTo)
-		b.sendMessage(msg.Chat.ID, m.ResStep7SalaryTo)
+		b.advance(chatID, userID, StateResumeWaitSalaryTo)
 
 	case StateResumeWaitSalaryTo:
-		if !isSkip(msg.Text) {
-			salary, err := strconv.Atoi(msg.Text)
-			if err != nil {
-				b.sendMessage(msg.Chat.ID, m.InvalidNumber)
-				return
-			}
-			draft := b.fsm.GetDraft(msg.From.ID)
-			if draft != nil && draft.SalaryFrom != nil && salary < *draft.SalaryFrom {
-				b.sendMessage(msg.Chat.ID, m.SalaryToLessThanFrom)
-				return
-			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.SalaryTo = &salary })
+		if !b.acceptSalaryTo(chatID, userID, msg.Text, m) {
+			return
 		}
-		b.fsm.SetState(msg.From.ID, StateResumeWaitAbout)
-		b.sendMessage(msg.Chat.ID, m.ResStep8About)
+		b.advance(chatID, userID, StateResumeWaitAbout)
 
 	case StateResumeWaitAbout:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.About = msg.Text })
-		b.fsm.SetState(msg.From.ID, StateResumeWaitContact)
-		b.sendMessage(msg.Chat.ID, m.ResStep9Contact)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.About = msg.Text })
+		b.advance(chatID, userID, StateResumeWaitContact)
 
 	case StateResumeWaitContact:
-		b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.ResumeContact = msg.Text })
-		b.fsm.SetState(msg.From.ID, StateResumeWaitLink)
-		b.sendResumeLinkStep(msg.Chat.ID, lang)
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.ResumeContact = msg.Text })
+		b.advance(chatID, userID, StateResumeWaitLink)
 
 	case StateResumeWaitLink:
 		// Check if it's a file (reject files)
 		if msg.Document != nil || msg.Photo != nil {
-			b.sendMessage(msg.Chat.ID, m.OnlyLinksAllowed)
+			b.sendMessage(chatID, m.OnlyLinksAllowed)
 			return
 		}
-		if !isSkip(msg.Text) {
-			// Validate it looks like a URL
+		if isSkip(msg.Text) {
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.ResumeLink = "" })
+		} else {
 			text := strings.TrimSpace(msg.Text)
-			if !strings.HasPrefix(text, "http://") && !strings.HasPrefix(text, "https://") && !strings.HasPrefix(text, "www.") {
-				b.sendMessage(msg.Chat.ID, m.OnlyLinksAllowed)
+			if !domain.IsLink(text) {
+				b.sendMessage(chatID, m.InvalidLink)
 				return
 			}
-			b.fsm.UpdateDraft(msg.From.ID, func(d *PostDraft) { d.ResumeLink = text })
+			b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.ResumeLink = text })
 		}
-		b.fsm.SetState(msg.From.ID, StateResumePreview)
-		b.sendResumePreview(msg.Chat.ID, msg.From.ID)
+		b.advance(chatID, userID, StateResumePreview)
+
+	case StateWaitPromoCode:
+		b.acceptPromoCode(chatID, userID, msg.Text, m)
 
 	default:
 		// Handle preview states - they wait for button clicks
 		if postType == domain.PostTypeResume && userState.State == StateResumePreview {
-			b.sendMessage(msg.Chat.ID, "Use buttons below / Используйте кнопки ниже")
+			b.sendMessage(chatID, "Use buttons below / Используйте кнопки ниже")
 		} else if userState.State == StatePreview {
-			b.sendMessage(msg.Chat.ID, "Use buttons below / Используйте кнопки ниже")
+			b.sendMessage(chatID, "Use buttons below / Используйте кнопки ниже")
+		}
+	}
+}
+
+// acceptSalaryFrom validates and stores the minimum salary ('skip' clears it)
+func (b *Bot) acceptSalaryFrom(chatID int64, userID int64, text string, m Messages) bool {
+	if isSkip(text) {
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.SalaryFrom = nil })
+		return true
+	}
+	salary, err := strconv.Atoi(text)
+	if err != nil || salary < 0 {
+		b.sendMessage(chatID, m.InvalidNumber)
+		return false
+	}
+	b.fsm.UpdateDraft(userID, func(d *PostDraft) {
+		d.SalaryFrom = &salary
+		// An edited minimum may exceed the old maximum; it is asked again next
+		if d.SalaryTo != nil && *d.SalaryTo < salary {
+			d.SalaryTo = nil
+		}
+	})
+	return true
+}
+
+// acceptSalaryTo validates and stores the maximum salary ('skip' clears it)
+func (b *Bot) acceptSalaryTo(chatID int64, userID int64, text string, m Messages) bool {
+	if isSkip(text) {
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.SalaryTo = nil })
+		return true
+	}
+	salary, err := strconv.Atoi(text)
+	if err != nil || salary < 0 {
+		b.sendMessage(chatID, m.InvalidNumber)
+		return false
+	}
+	draft := b.fsm.GetDraft(userID)
+	if draft != nil && draft.SalaryFrom != nil && salary < *draft.SalaryFrom {
+		b.sendMessage(chatID, m.SalaryToLessThanFrom)
+		return false
+	}
+	b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.SalaryTo = &salary })
+	return true
+}
+
+// advance moves the user to the next step of the flow. When a single field is
+// being edited from the preview, it returns straight to the preview instead
+// (the salary range is edited as a pair, so "from" still continues to "to").
+func (b *Bot) advance(chatID int64, userID int64, next State) {
+	b.fsm.update(userID, func(s *UserState) {
+		if s.Editing && next != StateWaitSalaryTo && next != StateResumeWaitSalaryTo {
+			next = previewState(s.Draft.PostType)
+		}
+		if next == previewState(s.Draft.PostType) {
+			s.Editing = false
+		}
+		s.State = next
+	})
+	b.sendStatePrompt(chatID, userID)
+}
+
+// goBack returns to the previous step keeping entered values.
+// While editing a single field it cancels the edit and returns to the preview.
+func (b *Bot) goBack(chatID int64, userID int64) {
+	b.fsm.update(userID, func(s *UserState) {
+		if s.Editing {
+			s.Editing = false
+			s.State = previewState(s.Draft.PostType)
+			return
 		}
+		if prev, ok := PreviousState(s.Draft.PostType, s.State); ok {
+			s.State = prev
+		}
+	})
+	b.sendStatePrompt(chatID, userID)
+}
+
+func previewState(postType domain.PostType) State {
+	if postType == domain.PostTypeResume {
+		return StateResumePreview
 	}
+	return StatePreview
 }
 
 // ==================== KEYBOARDS ====================
 
-func (b *Bot) sendLevelKeyboard(chatID int64, lang Language, prompt string) {
+func (b *Bot) sendPostTypeKeyboard(chatID int64, m Messages) {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Junior, "level:junior"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Middle, "level:middle"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Senior, "level:senior"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Vacancy, "post_type:vacancy"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Resume, "post_type:resume"),
+		),
+	)
+	b.sendMessageWithKeyboard(chatID, m.ChoosePostType, keyboard)
+}
+
+// choiceButton marks the currently selected option so it shows up as the default
+func choiceButton(label string, data string, selected bool) tgbotapi.InlineKeyboardButton {
+	if selected {
+		label = "✓ " + label
+	}
+	return tgbotapi.NewInlineKeyboardButtonData(label, data)
+}
+
+func levelRows(current domain.JobLevel) [][]tgbotapi.InlineKeyboardButton {
+	return [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			choiceButton(ButtonLabels.Junior, "level:junior", current == domain.JobLevelJunior),
+			choiceButton(ButtonLabels.Middle, "level:middle", current == domain.JobLevelMiddle),
+			choiceButton(ButtonLabels.Senior, "level:senior", current == domain.JobLevelSenior),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Internship, "level:internship"),
+			choiceButton(ButtonLabels.Internship, "level:internship", current == domain.JobLevelInternship),
 			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.SkipLevel, "level:skip"),
 		),
-	)
-	b.sendMessageWithKeyboard(chatID, prompt, keyboard)
+	}
 }
 
-func (b *Bot) sendTypeKeyboard(chatID int64, lang Language, prompt string) {
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+func typeRows(current domain.JobType) [][]tgbotapi.InlineKeyboardButton {
+	return [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Remote, "type:remote"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Hybrid, "type:hybrid"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Onsite, "type:onsite"),
+			choiceButton(ButtonLabels.Remote, "type:remote", current == domain.JobTypeRemote),
+			choiceButton(ButtonLabels.Hybrid, "type:hybrid", current == domain.JobTypeHybrid),
+			choiceButton(ButtonLabels.Onsite, "type:onsite", current == domain.JobTypeOnsite),
 		),
-	)
-	b.sendMessageWithKeyboard(chatID, prompt, keyboard)
+	}
 }
 
-func (b *Bot) sendCategoryKeyboard(chatID int64, lang Language) {
-	m := GetMessages(lang)
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+func categoryRows(current domain.JobCategory) [][]tgbotapi.InlineKeyboardButton {
+	return [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Other, "category:dev"),
+			choiceButton(ButtonLabels.Other, "category:dev", current == domain.JobCategoryDev),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Web2, "category:web2"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Web3, "category:web3"),
+			choiceButton(ButtonLabels.Web2, "category:web2", current == domain.JobCategoryWeb2),
+			choiceButton(ButtonLabels.Web3, "category:web3", current == domain.JobCategoryWeb3),
 		),
-	)
-	b.sendMessageWithKeyboard(chatID, m.VacStep6Category, keyboard)
+	}
 }
 
-func (b *Bot) sendEmploymentKeyboard(chatID int64, lang Language) {
-	m := GetMessages(lang)
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+func employmentRows(current domain.EmploymentType) [][]tgbotapi.InlineKeyboardButton {
+	return [][]tgbotapi.InlineKeyboardButton{
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.FullTime, "employment:full-time"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.PartTime, "employment:part-time"),
+			choiceButton(ButtonLabels.FullTime, "employment:full-time", current == domain.EmploymentFullTime),
+			choiceButton(ButtonLabels.PartTime, "employment:part-time", current == domain.EmploymentPartTime),
 		),
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Contract, "employment:contract"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Freelance, "employment:freelance"),
+			choiceButton(ButtonLabels.Contract, "employment:contract", current == domain.EmploymentContract),
+			choiceButton(ButtonLabels.Freelance, "employment:freelance", current == domain.EmploymentFreelance),
 		),
-	)
-	b.sendMessageWithKeyboard(chatID, m.ResStep5Employment, keyboard)
+	}
 }
 
-func (b *Bot) sendResumeLinkStep(chatID int64, lang Language) {
-	m := GetMessages(lang)
-	keyboard := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
+// sendStatePrompt sends the question for the user's current FSM state.
+// Already entered values are shown as defaults and a Back button is added.
+func (b *Bot) sendStatePrompt(chatID int64, userID int64) {
+	userState := b.fsm.GetState(userID)
+	m := GetMessages(userState.Language)
+	d := &userState.Draft
+
+	var text, current string
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	switch userState.State {
+	case StateWaitPostType:
+		b.sendPostTypeKeyboard(chatID, m)
+		return
+	case StatePreview:
+		b.sendVacancyPreview(chatID, userID)
+		return
+	case StateResumePreview:
+		b.sendResumePreview(chatID, userID)
+		return
+
+	// Vacancy
+	case StateWaitCompany:
+		text, current = m.VacStep1Company, d.Company
+	case StateWaitContact:
+		text, current = m.VacStep2Contact, d.Contact
+	case StateWaitTitle:
+		text, current = m.VacStep3Title, d.Title
+	case StateWaitLevel:
+		text, rows = m.VacStep4Level, levelRows(d.Level)
+	case StateWaitType:
+		text, rows = m.VacStep5Type, typeRows(d.Type)
+	case StateWaitCategory:
+		text, rows = m.VacStep6Category, categoryRows(d.Category)
+	case StateWaitDescription:
+		text, current = m.VacStep7Description, d.Description
+	case StateWaitSalaryFrom:
+		text, current = m.VacStep8SalaryFrom, formatOptionalInt(d.SalaryFrom)
+	case StateWaitSalaryTo:
+		text, current = m.VacStep9SalaryTo, formatOptionalInt(d.SalaryTo)
+	case StateWaitApplyLink:
+		text, current = m.VacStep10ApplyLink, d.ApplyLink
+
+	// Resume
+	case StateResumeWaitTitle:
+		text, current = m.ResStep1Title, d.Title
+	case StateResumeWaitLevel:
+		text, rows = m.ResStep2Level, levelRows(d.Level)
+	case StateResumeWaitExperience:
+		text = m.ResStep3Experience
+		if d.ExperienceYears != nil {
+			current = strconv.FormatFloat(*d.ExperienceYears, 'f', -1, 64)
+		}
+	case StateResumeWaitType:
+		text, rows = m.ResStep4Type, typeRows(d.Type)
+	case StateResumeWaitEmployment:
+		text, rows = m.ResStep5Employment, employmentRows(d.Employment)
+	case StateResumeWaitSalaryFrom:
+		text, current = m.ResStep6SalaryFrom, formatOptionalInt(d.SalaryFrom)
+	case StateResumeWaitSalaryTo:
+		text, current = m.ResStep7SalaryTo, formatOptionalInt(d.SalaryTo)
+	case StateResumeWaitAbout:
+		text, current = m.ResStep8About, d.About
+	case StateResumeWaitContact:
+		text, current = m.ResStep9Contact, d.ResumeContact
+	case StateResumeWaitLink:
+		text, current = m.ResStep10Link, d.ResumeLink
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Skip, "resume_link:skip"),
-		),
-	)
-	b.sendMessageWithKeyboard(chatID, m.ResStep10Link, keyboard)
+		))
+
+	case StateWaitPromoCode:
+		text = m.EnterPromoCode
+		if d.PromoCode != "" {
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.RemovePromoCode, "promo_remove"),
+			))
+		}
+
+	default:
+		b.sendMessage(chatID, m.DraftNotFound)
+		return
+	}
+
+	if current != "" {
+		text += "\n\n" + fmt.Sprintf(m.CurrentValue, escapeMarkdown(current))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.KeepCurrent, "keep"),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Back, "back"),
+	))
+
+	b.sendMessageWithKeyboard(chatID, text, tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+func formatOptionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
 }
 
 // ==================== PREVIEWS ====================
@@ -656,6 +960,11 @@ func (b *Bot) sendVacancyPreview(chatID int64, userID int64) {
 		levelDisplay = m.LevelNotSpecified
 	}
 
+	promoLine := ""
+	if draft.PromoCode != "" {
+		promoLine = fmt.Sprintf("\n\n🏷 *%s:* %s", m.PromoCodeLabel, escapeMarkdown(draft.PromoCode))
+	}
+
 	text := fmt.Sprintf(`%s
 
 🏢 *%s:* %s
@@ -682,16 +991,38 @@ func (b *Bot) sendVacancyPreview(chatID int64, userID int64) {
 		m.SalaryLabel, salary,
 		m.ApplyLinkLabel, escapeMarkdown(draft.ApplyLink),
 		m.DescriptionLabel,
-		escapeMarkdown(draft.Description),
+		escapeMarkdown(draft.Description)+promoLine,
 		m.PreviewConfirm,
 	)
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Submit, "submit"),
-			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Cancel, "cancel_submit"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditCompany, "edit:company"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditContact, "edit:contact"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditTitle, "edit:title"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditLevel, "edit:level"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditType, "edit:type"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditCategory, "edit:category"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditDescription, "edit:description"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditSalary, "edit:salary"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditApplyLink, "edit:apply_link"),
 		),
 	)
+	// Promo codes apply to paid placements of new vacancies only
+	if b.promoService != nil && draft.PostID == "" {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.PromoCode, "promo_enter"),
+		))
+	}
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Back, "back"),
+		tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Submit, "submit"),
+		tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Cancel, "cancel_submit"),
+	))
 
 	b.sendMessageWithKeyboard(chatID, text, keyboard)
 }
@@ -767,6 +1098,22 @@ func (b *Bot) sendResumePreview(chatID int64, userID int64) {
 
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditTitle, "edit:title"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditLevel, "edit:level"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditExperience, "edit:experience"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditType, "edit:type"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditEmployment, "edit:employment"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditSalary, "edit:salary"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditAbout, "edit:about"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditContact, "edit:contact"),
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.EditResumeLink, "edit:resume_link"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Back, "back"),
 			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Submit, "submit_resume"),
 			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.Cancel, "cancel_submit"),
 		),
@@ -816,15 +1163,12 @@ func (b *Bot) handleCallback(callback *tgbotapi.CallbackQuery) {
 		b.fsm.SetLanguage(userID, interfaceLang)
 		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Language = string(interfaceLang) })
 
-		m := GetMessages(interfaceLang)
-
 		if postType == domain.PostTypeResume {
 			b.fsm.SetState(userID, StateResumeWaitTitle)
-			b.sendMessage(chatID, m.ResStep1Title)
 		} else {
 			b.fsm.SetState(userID, StateWaitCompany)
-			b.sendMessage(chatID, m.VacStep1Company)
 		}
+		b.sendStatePrompt(chatID, userID)
 		return
 	}
 
@@ -832,8 +1176,46 @@ func (b *Bot) handleCallback(callback *tgbotapi.CallbackQuery) {
 	m := GetMessages(lang)
 	postType := b.fsm.GetPostType(userID)
 
+	// Edit one of the user's submitted posts
+	if strings.HasPrefix(data, "edit_post:") {
+		b.startPostEdit(chatID, userID, strings.TrimPrefix(data, "edit_post:"))
+		return
+	}
+
+	// Close (withdraw) one of the user's posts
+	if strings.HasPrefix(data, "close_post:") {
+		b.askCloseReason(chatID, userID, strings.TrimPrefix(data, "close_post:"))
+		return
+	}
+	if strings.HasPrefix(data, "close_reason:") {
+		b.closePost(chatID, userID, strings.TrimPrefix(data, "close_reason:"))
+		return
+	}
+
+	// Draft reminder actions
+	if data == "draft:continue" {
+		if b.fsm.GetState(userID).State == StateNone {
+			b.sendMessage(chatID, b.getInterfaceMessages(userID).DraftNotFound)
+			return
+		}
+		b.sendStatePrompt(chatID, userID)
+		return
+	}
+	if data == "draft:discard" {
+		b.fsm.Reset(userID)
+		b.sendMessage(chatID, m.DraftDiscarded)
+		return
+	}
+
+	// Choice buttons of old prompts stay clickable; they only apply to the
+	// step they were sent for
+	state := b.fsm.GetState(userID).State
+
 	// Level selection
 	if strings.HasPrefix(data, "level:") {
+		if state != StateWaitLevel && state != StateResumeWaitLevel {
+			return
+		}
 		levelStr := strings.TrimPrefix(data, "level:")
 		var level domain.JobLevel
 		if levelStr == "skip" {
@@ -844,52 +1226,84 @@ func (b *Bot) handleCallback(callback *tgbotapi.CallbackQuery) {
 		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Level = level })
 
 		if postType == domain.PostTypeResume {
-			b.fsm.SetState(userID, StateResumeWaitExperience)
-			b.sendMessage(chatID, m.ResStep3Experience)
+			b.advance(chatID, userID, StateResumeWaitExperience)
 		} else {
-			b.fsm.SetState(userID, StateWaitType)
-			b.sendTypeKeyboard(chatID, lang, m.VacStep5Type)
+			b.advance(chatID, userID, StateWaitType)
 		}
 		return
 	}
 
 	// Type selection
 	if strings.HasPrefix(data, "type:") {
+		if state != StateWaitType && state != StateResumeWaitType {
+			return
+		}
 		jobType := domain.JobType(strings.TrimPrefix(data, "type:"))
 		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Type = jobType })
 
 		if postType == domain.PostTypeResume {
-			b.fsm.SetState(userID, StateResumeWaitEmployment)
-			b.sendEmploymentKeyboard(chatID, lang)
+			b.advance(chatID, userID, StateResumeWaitEmployment)
 		} else {
-			b.fsm.SetState(userID, StateWaitCategory)
-			b.sendCategoryKeyboard(chatID, lang)
+			b.advance(chatID, userID, StateWaitCategory)
 		}
 		return
 	}
 
 	// Category selection (vacancy only)
 	if strings.HasPrefix(data, "category:") {
+		if state != StateWaitCategory {
+			return
+		}
 		category := domain.JobCategory(strings.TrimPrefix(data, "category:"))
 		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Category = category })
-		b.fsm.SetState(userID, StateWaitDescription)
-		b.sendMessage(chatID, m.VacStep7Description)
+		b.advance(chatID, userID, StateWaitDescription)
 		return
 	}
 
 	// Employment selection (resume only)
 	if strings.HasPrefix(data, "employment:") {
+		if state != StateResumeWaitEmployment {
+			return
+		}
 		emp := domain.EmploymentType(strings.TrimPrefix(data, "employment:"))
 		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.Employment = emp })
-		b.fsm.SetState(userID, StateResumeWaitSalaryFrom)
-		b.sendMessage(chatID, m.ResStep6SalaryFrom)
+		b.advance(chatID, userID, StateResumeWaitSalaryFrom)
 		return
 	}
 
 	// Resume link skip
 	if data == "resume_link:skip" {
-		b.fsm.SetState(userID, StateResumePreview)
-		b.sendResumePreview(chatID, userID)
+		if state != StateResumeWaitLink {
+			return
+		}
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.ResumeLink = "" })
+		b.advance(chatID, userID, StateResumePreview)
+		return
+	}
+
+	// Back / keep the current value
+	if data == "back" {
+		b.goBack(chatID, userID)
+		return
+	}
+	if data == "keep" {
+		if next, ok := NextState(postType, state); ok {
+			b.advance(chatID, userID, next)
+		}
+		return
+	}
+
+	// Edit a single field from the preview
+	if strings.HasPrefix(data, "edit:") {
+		state, ok := editFieldState(postType, strings.TrimPrefix(data, "edit:"))
+		if !ok {
+			return
+		}
+		b.fsm.update(userID, func(s *UserState) {
+			s.State = state
+			s.Editing = true
+		})
+		b.sendStatePrompt(chatID, userID)
 		return
 	}
 
@@ -905,6 +1319,46 @@ func (b *Bot) handleCallback(callback *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	// Promo code from the vacancy preview
+	if data == "promo_enter" {
+		b.fsm.update(userID, func(s *UserState) {
+			s.State = StateWaitPromoCode
+			s.Editing = true
+		})
+		b.sendStatePrompt(chatID, userID)
+		return
+	}
+
+	if data == "promo_remove" {
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.PromoCode = "" })
+		b.advance(chatID, userID, StatePreview)
+		return
+	}
+
+	// Telegram invoice for a submitted vacancy
+	if strings.HasPrefix(data, "pay:") {
+		b.sendInvoice(callback)
+		return
+	}
+
+	// Search filters and pages
+	if strings.HasPrefix(data, "search_f:") || strings.HasPrefix(data, "search_p:") {
+		b.handleSearchCallback(callback)
+		return
+	}
+
+	// Job alerts
+	if strings.HasPrefix(data, "unsub:") {
+		b.unsubscribe(callback)
+		return
+	}
+
+	if data == "pay_later" {
+		note := b.getInterfaceMessages(userID).PayLaterNote
+		b.api.Send(tgbotapi.NewEditMessageText(chatID, callback.Message.MessageID, note))
+		return
+	}
+
 	// Cancel
 	if data == "cancel_submit" {
 		b.fsm.Reset(userID)
@@ -913,12 +1367,56 @@ func (b *Bot) handleCallback(callback *tgbotapi.CallbackQuery) {
 	}
 
 	// Admin callbacks
-	if strings.HasPrefix(data, "approve:") || strings.HasPrefix(data, "reject:") ||
+	if strings.HasPrefix(data, "approve:") || strings.HasPrefix(data, "approve_featured:") ||
+		strings.HasPrefix(data, "reject:") ||
 		strings.HasPrefix(data, "delete:") || strings.HasPrefix(data, "confirm_delete:") ||
-		strings.HasPrefix(data, "cancel_delete:") {
+		strings.HasPrefix(data, "cancel_delete:") ||
+		strings.HasPrefix(data, "approve_rev:") || strings.HasPrefix(data, "reject_rev:") ||
+		strings.HasPrefix(data, "reject_reason:") || strings.HasPrefix(data, "reject_other:") ||
+		strings.HasPrefix(data, "reject_back:") ||
+		strings.HasPrefix(data, "schedule:") || strings.HasPrefix(data, "schedule_at:") ||
+		strings.HasPrefix(data, "schedule_back:") ||
+		strings.HasPrefix(data, "retry_publish:") {
 		b.handleAdminCallback(callback)
 		return
 	}
+
+	if strings.HasPrefix(data, "payment_paid:") || strings.HasPrefix(data, "payment_failed:") {
+		b.handlePaymentCallback(callback)
+		return
+	}
+}
+
+// editFieldState maps an "edit:<field>" callback to the FSM state asking for that field
+func editFieldState(postType domain.PostType, field string) (State, bool) {
+	var states map[string]State
+	if postType == domain.PostTypeResume {
+		states = map[string]State{
+			"title":       StateResumeWaitTitle,
+			"level":       StateResumeWaitLevel,
+			"experience":  StateResumeWaitExperience,
+			"type":        StateResumeWaitType,
+			"employment":  StateResumeWaitEmployment,
+			"salary":      StateResumeWaitSalaryFrom,
+			"about":       StateResumeWaitAbout,
+			"contact":     StateResumeWaitContact,
+			"resume_link": StateResumeWaitLink,
+		}
+	} else {
+		states = map[string]State{
+			"company":     StateWaitCompany,
+			"contact":     StateWaitContact,
+			"title":       StateWaitTitle,
+			"level":       StateWaitLevel,
+			"type":        StateWaitType,
+			"category":    StateWaitCategory,
+			"description": StateWaitDescription,
+			"salary":      StateWaitSalaryFrom,
+			"apply_link":  StateWaitApplyLink,
+		}
+	}
+	state, ok := states[field]
+	return state, ok
 }
 
 // ==================== SUBMIT ====================
@@ -935,10 +1433,25 @@ func (b *Bot) submitVacancy(callback *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	if draft.PostID != "" {
+		b.submitPostEdit(callback, draft)
+		return
+	}
+
 	ctx := context.Background()
 	username := callback.From.UserName
 
 	job, err := b.jobService.CreateJob(ctx, userID, username, draft.ToCreateJobRequest())
+	if err == service.ErrInvalidPromoCode {
+		// The code expired or ran out while the draft was open: drop it and show the preview again
+		b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.PromoCode = "" })
+		b.sendMessage(chatID, m.PromoCodeInvalid)
+		b.sendVacancyPreview(chatID, userID)
+		return
+	}
+	if b.rejectedFields(chatID, userID, err, m) {
+		return
+	}
 	if err != nil {
 		log.Printf("Error creating vacancy: %v", err)
 		b.sendMessage(chatID, m.SubmitError+err.Error())
@@ -947,6 +1460,7 @@ func (b *Bot) submitVacancy(callback *tgbotapi.CallbackQuery) {
 
 	b.fsm.Reset(userID)
 	b.sendMessage(chatID, fmt.Sprintf(m.SubmitVacancySuccess, job.ID.String()))
+	b.offerInvoice(chatID, job.ID, m)
 }
 
 func (b *Bot) submitResume(callback *tgbotapi.CallbackQuery) {
@@ -961,10 +1475,18 @@ func (b *Bot) submitResume(callback *tgbotapi.CallbackQuery) {
 		return
 	}
 
+	if draft.PostID != "" {
+		b.submitPostEdit(callback, draft)
+		return
+	}
+
 	ctx := context.Background()
 	username := callback.From.UserName
 
 	resume, err := b.jobService.CreateResume(ctx, userID, username, draft.ToCreateResumeRequest())
+	if b.rejectedFields(chatID, userID, err, m) {
+		return
+	}
 	if err != nil {
 		log.Printf("Error creating resume: %v", err)
 		b.sendMessage(chatID, m.SubmitError+err.Error())
@@ -975,29 +1497,130 @@ func (b *Bot) submitResume(callback *tgbotapi.CallbackQuery) {
 	b.sendMessage(chatID, fmt.Sprintf(m.SubmitResumeSuccess, resume.ID.String()))
 }
 
-// Keep old submitJob for backward compatibility
-func (b *Bot) submitJob(callback *tgbotapi.CallbackQuery) {
-	b.submitVacancy(callback)
+// rejectedFields reports the fields JobService didn't accept and shows the
+// preview again, so they can be edited. It returns false for other errors.
+func (b *Bot) rejectedFields(chatID int64, userID int64, err error, m Messages) bool {
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+
+	var sb strings.Builder
+	sb.WriteString(m.InvalidFields)
+	for _, f := range validationErr.Fields {
+		sb.WriteString("\n• ")
+		sb.WriteString(fieldErrorText(f, m))
+	}
+	b.sendMessage(chatID, sb.String())
+
+	if draft := b.fsm.GetDraft(userID); draft != nil && draft.PostType == domain.PostTypeResume {
+		b.sendResumePreview(chatID, userID)
+	} else {
+		b.sendVacancyPreview(chatID, userID)
+	}
+	return true
 }
 
-// ==================== VALIDATORS ====================
+func fieldErrorText(f domain.FieldError, m Messages) string {
+	label := fieldLabel(f.Field, m)
+	switch f.Code {
+	case domain.CodeRequired:
+		return fmt.Sprintf(m.FieldRequired, label)
+	case domain.CodeNegative:
+		return fmt.Sprintf(m.FieldNegative, label)
+	case domain.CodeBelowMin:
+		return fmt.Sprintf(m.FieldBelowMin, label)
+	case domain.CodeAboveMax:
+		return fmt.Sprintf(m.FieldAboveMax, label)
+	case domain.CodeInvalidLink:
+		return fmt.Sprintf(m.FieldInvalidLink, label)
+	default:
+		return fmt.Sprintf(m.FieldInvalid, label)
+	}
+}
 
-func isValidLevel(level domain.JobLevel) bool {
-	return level == domain.JobLevelJunior || level == domain.JobLevelMiddle ||
-		level == domain.JobLevelSenior || level == domain.JobLevelInternship
+// fieldLabel names a request field as the preview does
+func fieldLabel(field string, m Messages) string {
+	switch field {
+	case "company":
+		return m.CompanyLabel
+	case "contact":
+		return m.ContactLabel
+	case "title":
+		return m.TitleLabel
+	case "level":
+		return m.LevelLabel
+	case "type":
+		return m.TypeLabel
+	case "category":
+		return m.CategoryLabel
+	case "salary_from", "salary_to":
+		return m.SalaryLabel
+	case "description":
+		return m.DescriptionLabel
+	case "apply_link":
+		return m.ApplyLinkLabel
+	case "experience_years":
+		return m.ExperienceLabel
+	case "employment":
+		return m.EmploymentLabel
+	case "about":
+		return m.AboutLabel
+	case "resume_link":
+		return m.ResumeLinkLabel
+	case "promo_code":
+		return m.PromoCodeLabel
+	default:
+		return escapeMarkdown(field)
+	}
 }
 
-func isValidType(t domain.JobType) bool {
-	return t == domain.JobTypeRemote || t == domain.JobTypeHybrid || t == domain.JobTypeOnsite
+// submitPostEdit sends changes of an existing post (opened from /myjobs)
+func (b *Bot) submitPostEdit(callback *tgbotapi.CallbackQuery, draft *PostDraft) {
+	userID := callback.From.ID
+	chatID := callback.Message.Chat.ID
+	m := GetMessages(b.fsm.GetLanguage(userID))
+
+	postID, err := uuid.Parse(draft.PostID)
+	if err != nil {
+		b.sendMessage(chatID, m.CannotEditPost)
+		return
+	}
+
+	ctx := context.Background()
+	var post *domain.PostWithDetails
+	if draft.PostType == domain.PostTypeResume {
+		post, err = b.jobService.UpdateResume(ctx, botPrincipal(userID), postID, draft.ToCreateResumeRequest())
+	} else {
+		post, err = b.jobService.UpdateJob(ctx, botPrincipal(userID), postID, draft.ToCreateJobRequest())
+	}
+	if b.rejectedFields(chatID, userID, err, m) {
+		return
+	}
+	if err != nil {
+		log.Printf("Error updating post %s: %v", draft.PostID, err)
+		b.sendMessage(chatID, m.SubmitError+err.Error())
+		return
+	}
+
+	b.fsm.Reset(userID)
+	if post.Status == domain.JobStatusPending {
+		b.sendMessage(chatID, m.PostUpdated)
+	} else {
+		b.sendMessage(chatID, m.RevisionSubmitted)
+	}
 }
 
-func isValidCategory(c domain.JobCategory) bool {
-	return c == domain.JobCategoryWeb2 || c == domain.JobCategoryWeb3 || c == domain.JobCategoryDev
+// Keep old submitJob for backward compatibility
+func (b *Bot) submitJob(callback *tgbotapi.CallbackQuery) {
+	b.submitVacancy(callback)
 }
 
-func isValidEmployment(e domain.EmploymentType) bool {
-	return e == domain.EmploymentFullTime || e == domain.EmploymentPartTime ||
-		e == domain.EmploymentContract || e == domain.EmploymentFreelance
+// ==================== VALIDATORS ====================
+
+func isBack(text string) bool {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	return lower == "back" || lower == "назад"
 }
 
 func isSkip(text string) bool {
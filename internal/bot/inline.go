@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"telegram-job/internal/publisher"
+)
+
+const (
+	inlineResultsLimit = 20
+	inlineCacheSeconds = 60
+	// maxInlineOffset bounds how deep inline results can be paged; the offset
+	// comes back from the client and is not trusted
+	maxInlineOffset = 1000
+)
+
+// handleInlineQuery answers "@bot golang senior" in any chat with matching
+// published posts, rendered the same way as in the channel
+func (b *Bot) handleInlineQuery(query *tgbotapi.InlineQuery) {
+	m := b.getInterfaceMessages(query.From.ID)
+
+	offset, err := strconv.Atoi(query.Offset)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	if offset > maxInlineOffset {
+		offset = maxInlineOffset
+	}
+
+	posts, total, err := b.jobService.Search(context.Background(), parseSearchQuery(query.Query), inlineResultsLimit, offset)
+	if err != nil {
+		log.Printf("Error answering inline query %q: %v", query.Query, err)
+		return
+	}
+
+	results := make([]interface{}, 0, len(posts))
+	for _, post := range posts {
+		article := tgbotapi.NewInlineQueryResultArticle(post.ID.String(), post.Title, "")
+		article.InputMessageContent = tgbotapi.InputTextMessageContent{
+			Text:                  publisher.FormatPost(&post),
+			ParseMode:             "Markdown",
+			DisableWebPagePreview: true,
+		}
+		article.Description = publisher.PostDetails(&post, m.SalaryFromLabel)
+
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL(ButtonLabels.OpenInChannel, b.cfg.ChannelPostLink(*post.ChannelMessageID)),
+		))
+		article.ReplyMarkup = &keyboard
+
+		results = append(results, article)
+	}
+
+	nextOffset := ""
+	if next := offset + len(posts); next < total && next <= maxInlineOffset {
+		nextOffset = strconv.Itoa(next)
+	}
+
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       results,
+		CacheTime:     inlineCacheSeconds,
+		NextOffset:    nextOffset,
+	}
+	if _, err := b.api.Request(answer); err != nil {
+		log.Printf("Error answering inline query %q: %v", query.Query, err)
+	}
+}
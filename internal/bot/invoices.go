@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+)
+
+// invoicesEnabled reports whether recruiters can pay with a Telegram invoice
+func (b *Bot) invoicesEnabled() bool {
+	return b.paymentService != nil && b.cfg.PaymentProviderToken != ""
+}
+
+// offerInvoice asks the author of a freshly submitted vacancy to pick a paid placement
+func (b *Bot) offerInvoice(chatID int64, postID uuid.UUID, m Messages) {
+	if !b.invoicesEnabled() {
+		return
+	}
+
+	id := postID.String()
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.PayStandard, b.formatPrice(postID, domain.PlacementStandard)), "pay:"+id+":"+string(domain.PlacementStandard)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.PayFeatured, b.formatPrice(postID, domain.PlacementFeatured)), "pay:"+id+":"+string(domain.PlacementFeatured)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.PayLater, "pay_later"),
+		),
+	)
+	b.sendMessageWithKeyboard(chatID, m.ChoosePlacement, keyboard)
+}
+
+// sendInvoice handles "pay:<post id>:<tier>" and sends the Telegram invoice
+func (b *Bot) sendInvoice(callback *tgbotapi.CallbackQuery) {
+	userID := callback.From.ID
+	chatID := callback.Message.Chat.ID
+	m := b.getInterfaceMessages(userID)
+
+	postID, tier, ok := parseInvoicePayload(strings.TrimPrefix(callback.Data, "pay:"))
+	if !ok || !b.invoicesEnabled() {
+		return
+	}
+
+	ctx := context.Background()
+	price := b.paymentService.PriceFor(ctx, postID, tier)
+	if err := b.paymentService.CheckInvoice(ctx, userID, postID, tier, price, b.cfg.PaymentCurrency); err != nil {
+		b.sendMessage(chatID, m.PaymentUnavailable)
+		return
+	}
+
+	post, err := b.jobService.GetJob(ctx, postID)
+	if err != nil {
+		b.sendMessage(chatID, m.PaymentUnavailable)
+		return
+	}
+
+	title := m.InvoiceStandard
+	if tier == domain.PlacementFeatured {
+		title = m.InvoiceFeatured
+	}
+
+	invoice := tgbotapi.NewInvoice(chatID, title, truncateRunes(post.Title, 255),
+		invoicePayload(postID, tier), b.cfg.PaymentProviderToken, "", b.cfg.PaymentCurrency,
+		[]tgbotapi.LabeledPrice{{Label: title, Amount: price}})
+	if _, err := b.api.Send(invoice); err != nil {
+		log.Printf("Error sending invoice for post %s: %v", postID, err)
+		b.sendMessage(chatID, m.PaymentUnavailable)
+	}
+}
+
+// handlePreCheckout confirms the order is still valid before Telegram charges the user
+func (b *Bot) handlePreCheckout(query *tgbotapi.PreCheckoutQuery) {
+	m := b.getInterfaceMessages(query.From.ID)
+	answer := tgbotapi.PreCheckoutConfig{PreCheckoutQueryID: query.ID, OK: true}
+
+	postID, tier, ok := parseInvoicePayload(strings.TrimPrefix(query.InvoicePayload, "post:"))
+	if !ok || !b.invoicesEnabled() {
+		answer.OK = false
+	} else if err := b.paymentService.CheckInvoice(context.Background(), query.From.ID, postID, tier, query.TotalAmount, query.Currency); err != nil {
+		log.Printf("Pre-checkout for post %s declined: %v", postID, err)
+		answer.OK = false
+	}
+	if !answer.OK {
+		answer.ErrorMessage = m.PaymentUnavailable
+	}
+
+	if _, err := b.api.Request(answer); err != nil {
+		log.Printf("Error answering pre-checkout query: %v", err)
+	}
+}
+
+// handleSuccessfulPayment records the payment and shows admins a PAID moderation card
+func (b *Bot) handleSuccessfulPayment(msg *tgbotapi.Message) {
+	payment := msg.SuccessfulPayment
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	postID, tier, ok := parseInvoicePayload(strings.TrimPrefix(payment.InvoicePayload, "post:"))
+	if !ok || b.paymentService == nil {
+		log.Printf("Unknown invoice payload %q (charge %s)", payment.InvoicePayload, payment.TelegramPaymentChargeID)
+		return
+	}
+
+	ctx := context.Background()
+	recorded, created, err := b.paymentService.RecordTelegramPayment(ctx, postID, tier,
+		payment.TotalAmount, payment.Currency, payment.TelegramPaymentChargeID)
+	if err != nil {
+		log.Printf("Error recording payment %s for post %s: %v", payment.TelegramPaymentChargeID, postID, err)
+		return
+	}
+	if !created {
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, m.PaymentReceived)
+
+	post, err := b.jobService.GetJobWithCompany(ctx, postID)
+	if err != nil {
+		log.Printf("Error loading paid post %s: %v", postID, err)
+		return
+	}
+	notifier := NewAdminNotifier(b.api, b.cfg.AdminTelegramIDs)
+	_ = notifier.NotifyPaid(ctx, post, recorded)
+}
+
+// formatPrice shows the price of a tier for a post, with its promo code applied
+func (b *Bot) formatPrice(postID uuid.UUID, tier domain.PlacementTier) string {
+	price := b.paymentService.PriceFor(context.Background(), postID, tier)
+	return formatAmount(price) + " " + b.cfg.PaymentCurrency
+}
+
+func invoicePayload(postID uuid.UUID, tier domain.PlacementTier) string {
+	return "post:" + postID.String() + ":" + string(tier)
+}
+
+// parseInvoicePayload parses "<post id>:<tier>"
+func parseInvoicePayload(s string) (uuid.UUID, domain.PlacementTier, bool) {
+	idStr, tierStr, found := strings.Cut(s, ":")
+	if !found {
+		return uuid.Nil, "", false
+	}
+	postID, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, "", false
+	}
+	tier := domain.PlacementTier(tierStr)
+	if tier != domain.PlacementStandard && tier != domain.PlacementFeatured {
+		return uuid.Nil, "", false
+	}
+	return postID, tier, true
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
@@ -0,0 +1,313 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+	"telegram-job/internal/service"
+	"telegram-job/internal/testutil"
+)
+
+const (
+	testAdminID  int64 = 1001
+	testAuthorID int64 = 2002
+)
+
+// invoiceTest is a bot wired to a fake Bot API and a test database, with
+// one pending vacancy submitted by testAuthorID
+type invoiceTest struct {
+	bot         *Bot
+	fake        *testutil.FakeBotAPI
+	paymentRepo *repository.PaymentRepository
+	postID      uuid.UUID
+}
+
+func newInvoiceTest(t *testing.T) *invoiceTest {
+	t.Helper()
+
+	db := testutil.OpenDB(t)
+	fake := testutil.NewFakeBotAPI(t)
+	cfg := &config.Config{
+		ChannelID:             -1001234567890,
+		AdminTelegramIDs:      map[int64]bool{testAdminID: true},
+		ChannelPostsPerMinute: 20,
+		FeaturedPinHours:      48,
+		PaymentProviderToken:  "provider-token",
+		PaymentCurrency:       "USD",
+		PriceStandard:         5000,
+		PriceFeatured:         15000,
+	}
+
+	jobRepo := repository.NewJobRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	promoRepo := repository.NewPromoCodeRepository(db)
+	jobService := service.NewJobService(cfg, jobRepo, repository.NewCompanyRepository(db), userRepo,
+		repository.NewRevisionRepository(db), repository.NewPostEventRepository(db), repository.NewPublishOutboxRepository(db),
+		paymentRepo, promoRepo, nil, nil)
+
+	post, err := jobService.CreateJob(context.Background(), testAuthorID, "author", &domain.CreateJobRequest{
+		Company:     "Acme",
+		Contact:     "@acme_hr",
+		Title:       "Go developer",
+		Level:       domain.JobLevelSenior,
+		Type:        domain.JobTypeRemote,
+		Category:    domain.JobCategoryDev,
+		Description: "Backend services in Go",
+		ApplyLink:   "https://example.com/apply",
+		Language:    "en",
+	})
+	if err != nil {
+		t.Fatalf("creating vacancy: %v", err)
+	}
+
+	return &invoiceTest{
+		bot: &Bot{
+			api:            fake.API(t),
+			cfg:            cfg,
+			jobService:     jobService,
+			userRepo:       userRepo,
+			fsm:            NewFSM(),
+			paymentService: service.NewPaymentService(cfg, paymentRepo, jobRepo, promoRepo),
+		},
+		fake:        fake,
+		paymentRepo: paymentRepo,
+		postID:      post.ID,
+	}
+}
+
+func (it *invoiceTest) preCheckout(from int64, payload string, amount int) tgbotapi.Update {
+	return tgbotapi.Update{PreCheckoutQuery: &tgbotapi.PreCheckoutQuery{
+		ID:             "query-" + strconv.Itoa(amount),
+		From:           &tgbotapi.User{ID: from},
+		Currency:       "USD",
+		TotalAmount:    amount,
+		InvoicePayload: payload,
+	}}
+}
+
+func (it *invoiceTest) successfulPayment(chargeID string, tier domain.PlacementTier, amount int) tgbotapi.Update {
+	return tgbotapi.Update{Message: &tgbotapi.Message{
+		MessageID: 10,
+		From:      &tgbotapi.User{ID: testAuthorID},
+		Chat:      &tgbotapi.Chat{ID: testAuthorID},
+		SuccessfulPayment: &tgbotapi.SuccessfulPayment{
+			Currency:                "USD",
+			TotalAmount:             amount,
+			InvoicePayload:          invoicePayload(it.postID, tier),
+			TelegramPaymentChargeID: chargeID,
+			ProviderPaymentChargeID: "provider-" + chargeID,
+		},
+	}}
+}
+
+// sentTo returns the calls of method addressed to chatID
+func (it *invoiceTest) sentTo(method string, chatID int64) []testutil.BotAPICall {
+	var calls []testutil.BotAPICall
+	for _, call := range it.fake.Calls(method) {
+		if call.Params.Get("chat_id") == strconv.FormatInt(chatID, 10) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func TestSendInvoice(t *testing.T) {
+	it := newInvoiceTest(t)
+
+	for _, tc := range []struct {
+		tier   domain.PlacementTier
+		amount string
+	}{
+		{domain.PlacementStandard, `"amount":5000`},
+		{domain.PlacementFeatured, `"amount":15000`},
+	} {
+		it.bot.handleUpdate(tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			ID:      "callback",
+			From:    &tgbotapi.User{ID: testAuthorID},
+			Message: &tgbotapi.Message{MessageID: 5, Chat: &tgbotapi.Chat{ID: testAuthorID}},
+			Data:    "pay:" + it.postID.String() + ":" + string(tc.tier),
+		}})
+
+		invoices := it.fake.Calls("sendInvoice")
+		if len(invoices) == 0 {
+			t.Fatalf("%s: no invoice sent", tc.tier)
+		}
+		params := invoices[len(invoices)-1].Params
+		if got := params.Get("chat_id"); got != strconv.FormatInt(testAuthorID, 10) {
+			t.Errorf("%s: chat_id = %s, want the author", tc.tier, got)
+		}
+		if got, want := params.Get("payload"), invoicePayload(it.postID, tc.tier); got != want {
+			t.Errorf("%s: payload = %q, want %q", tc.tier, got, want)
+		}
+		if got := params.Get("provider_token"); got != "provider-token" {
+			t.Errorf("%s: provider_token = %q", tc.tier, got)
+		}
+		if got := params.Get("currency"); got != "USD" {
+			t.Errorf("%s: currency = %q, want USD", tc.tier, got)
+		}
+		if prices := params.Get("prices"); !strings.Contains(prices, tc.amount) {
+			t.Errorf("%s: prices = %s, want %s", tc.tier, prices, tc.amount)
+		}
+	}
+}
+
+func TestPreCheckoutAcceptsValidInvoice(t *testing.T) {
+	it := newInvoiceTest(t)
+
+	it.bot.handleUpdate(it.preCheckout(testAuthorID, invoicePayload(it.postID, domain.PlacementStandard), 5000))
+
+	answers := it.fake.Calls("answerPreCheckoutQuery")
+	if len(answers) != 1 {
+		t.Fatalf("answerPreCheckoutQuery called %d times, want 1", len(answers))
+	}
+	if got := answers[0].Params.Get("ok"); got != "true" {
+		t.Errorf("ok = %q, want true (error: %q)", got, answers[0].Params.Get("error_message"))
+	}
+}
+
+func TestPreCheckoutDeclinesInvalidInvoice(t *testing.T) {
+	it := newInvoiceTest(t)
+	payload := invoicePayload(it.postID, domain.PlacementStandard)
+
+	for name, update := range map[string]tgbotapi.Update{
+		"wrong amount":    it.preCheckout(testAuthorID, payload, 100),
+		"not the author":  it.preCheckout(testAuthorID+1, payload, 5000),
+		"unknown payload": it.preCheckout(testAuthorID, "post:not-a-post:standard", 5000),
+		"unknown tier":    it.preCheckout(testAuthorID, "post:"+it.postID.String()+":gold", 5000),
+	} {
+		before := len(it.fake.Calls("answerPreCheckoutQuery"))
+		it.bot.handleUpdate(update)
+
+		answers := it.fake.Calls("answerPreCheckoutQuery")
+		if len(answers) != before+1 {
+			t.Fatalf("%s: answerPreCheckoutQuery called %d times, want 1", name, len(answers)-before)
+		}
+		params := answers[len(answers)-1].Params
+		if params.Get("ok") == "true" {
+			t.Errorf("%s: pre-checkout accepted, want declined", name)
+		}
+		if params.Get("error_message") == "" {
+			t.Errorf("%s: declined without an error message", name)
+		}
+	}
+}
+
+func TestSuccessfulPaymentRecordsLedgerRow(t *testing.T) {
+	it := newInvoiceTest(t)
+
+	it.bot.handleUpdate(it.successfulPayment("charge-1", domain.PlacementStandard, 5000))
+
+	payments, err := it.paymentRepo.ListByPost(context.Background(), it.postID)
+	if err != nil {
+		t.Fatalf("listing payments: %v", err)
+	}
+	if len(payments) != 1 {
+		t.Fatalf("%d payments recorded, want 1", len(payments))
+	}
+	p := payments[0]
+	if p.Status != domain.PaymentStatusPaid {
+		t.Errorf("status = %s, want paid", p.Status)
+	}
+	if p.Amount != 5000 || p.Currency != "USD" {
+		t.Errorf("amount = %d %s, want 5000 USD", p.Amount, p.Currency)
+	}
+	if p.ExternalID != "charge-1" {
+		t.Errorf("external ID = %q, want the charge ID", p.ExternalID)
+	}
+	if p.Method != "telegram" {
+		t.Errorf("method = %q, want telegram", p.Method)
+	}
+	if p.Tier == nil || *p.Tier != domain.PlacementStandard {
+		t.Errorf("tier = %v, want standard", p.Tier)
+	}
+	if p.PaidAt == nil {
+		t.Error("paid_at is not set")
+	}
+
+	if n := len(it.sentTo("sendMessage", testAuthorID)); n != 1 {
+		t.Errorf("author got %d messages, want 1 payment confirmation", n)
+	}
+	cards := it.sentTo("sendMessage", testAdminID)
+	if len(cards) != 1 {
+		t.Fatalf("admin got %d messages, want 1 PAID card", len(cards))
+	}
+	if text := cards[0].Params.Get("text"); !strings.Contains(text, "Paid via Telegram") {
+		t.Errorf("admin card isn't marked as paid: %q", text)
+	}
+
+	// Once paid, the same post can't be paid again
+	it.bot.handleUpdate(it.preCheckout(testAuthorID, invoicePayload(it.postID, domain.PlacementStandard), 5000))
+	answers := it.fake.Calls("answerPreCheckoutQuery")
+	if len(answers) != 1 || answers[0].Params.Get("ok") == "true" {
+		t.Error("pre-checkout of a paid post was accepted")
+	}
+}
+
+func TestDuplicateSuccessfulPaymentIsIdempotent(t *testing.T) {
+	it := newInvoiceTest(t)
+
+	update := it.successfulPayment("charge-1", domain.PlacementFeatured, 15000)
+	it.bot.handleUpdate(update)
+	it.bot.handleUpdate(update)
+
+	payments, err := it.paymentRepo.ListByPost(context.Background(), it.postID)
+	if err != nil {
+		t.Fatalf("listing payments: %v", err)
+	}
+	if len(payments) != 1 {
+		t.Fatalf("%d payments recorded for one charge, want 1", len(payments))
+	}
+	if n := len(it.sentTo("sendMessage", testAuthorID)); n != 1 {
+		t.Errorf("author got %d confirmations, want 1", n)
+	}
+	if n := len(it.sentTo("sendMessage", testAdminID)); n != 1 {
+		t.Errorf("admin got %d PAID cards, want 1", n)
+	}
+}
+
+func TestInvoicePayloadRoundTrip(t *testing.T) {
+	postID := uuid.New()
+	for _, tier := range []domain.PlacementTier{domain.PlacementStandard, domain.PlacementFeatured} {
+		payload := invoicePayload(postID, tier)
+		if len(payload) > 128 {
+			t.Errorf("payload %q is longer than Telegram's 128 bytes", payload)
+		}
+
+		gotID, gotTier, ok := parseInvoicePayload(strings.TrimPrefix(payload, "post:"))
+		if !ok || gotID != postID || gotTier != tier {
+			t.Errorf("parse(%q) = %s, %s, %v", payload, gotID, gotTier, ok)
+		}
+	}
+}
+
+func TestParseInvoicePayloadRejectsUnknown(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"standard",
+		uuid.NewString(),
+		"not-a-post:standard",
+		uuid.NewString() + ":gold",
+		uuid.NewString() + ":",
+	} {
+		if _, _, ok := parseInvoicePayload(s); ok {
+			t.Errorf("parseInvoicePayload(%q) accepted", s)
+		}
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("Разработчик", 3); got != "Раз" {
+		t.Errorf("truncateRunes = %q, want %q", got, "Раз")
+	}
+	if got := truncateRunes("Go", 255); got != "Go" {
+		t.Errorf("truncateRunes = %q, want it unchanged", got)
+	}
+}
@@ -2,19 +2,19 @@ package bot
 
 type Messages struct {
 	// Interface messages
-	Welcome              string
-	Help                 string
-	HelpAdmin            string
-	UnknownCommand       string
-	LanguageSet          string
-	ChooseLanguage       string
-	ChoosePostLanguage   string
-	NoPosts              string
-	YourPosts            string
-	NoPermission         string
-	NoPendingPosts       string
-	PendingPostsCount    string
-	StatsTitle           string
+	Welcome            string
+	Help               string
+	HelpAdmin          string
+	UnknownCommand     string
+	LanguageSet        string
+	ChooseLanguage     string
+	ChoosePostLanguage string
+	NoPosts            string
+	YourPosts          string
+	NoPermission       string
+	NoPendingPosts     string
+	PendingPostsCount  string
+	StatsTitle         string
 	// FAQ
 	FAQ     string
 	About   string
@@ -65,6 +65,74 @@ type Messages struct {
 	SalaryToLessThanFrom string
 	InvalidExperience    string
 	OnlyLinksAllowed     string
+	InvalidLink          string
+
+	// Fields rejected on submit; %s is the field label
+	InvalidFields    string
+	FieldRequired    string
+	FieldInvalid     string
+	FieldNegative    string
+	FieldBelowMin    string
+	FieldAboveMax    string
+	FieldInvalidLink string
+
+	// Draft reminders
+	DraftReminder  string
+	DraftDiscarded string
+	DraftNotFound  string
+	CurrentValue   string
+
+	// Editing submitted posts
+	PostUpdated       string
+	RevisionSubmitted string
+	CannotEditPost    string
+	ChooseCloseReason string
+	PostClosed        string
+	CannotClosePost   string
+
+	// Prepaid packages
+	BalanceTitle    string
+	NoPackages      string
+	PackageLine     string
+	PackageNoExpiry string
+
+	// Telegram Payments
+	ChoosePlacement    string
+	InvoiceStandard    string
+	InvoiceFeatured    string
+	PaymentReceived    string
+	PaymentUnavailable string
+	PayLaterNote       string
+
+	// Promo codes
+	PromoCodeLabel   string
+	EnterPromoCode   string
+	PromoCodeApplied string
+	PromoCodeInvalid string
+
+	// Job alerts
+	SubscribeHelp       string
+	SubscriptionSaved   string
+	SubscriptionLimit   string
+	NoSubscriptions     string
+	YourSubscriptions   string
+	SubscriptionDeleted string
+	AllPosts            string
+	FrequencyDaily      string
+	FrequencyWeekly     string
+
+	// Search
+	SearchTitle     string
+	SearchAnything  string
+	SearchResults   string
+	SearchNoResults string
+	SearchExpired   string
+
+	// Digests
+	DigestDailyTitle  string
+	DigestWeeklyTitle string
+	DigestMore        string
+	DigestFooter      string
 
 	// Level buttons
 	LevelJunior       string
@@ -91,24 +159,24 @@ type Messages struct {
 	EmploymentFreelance string
 
 	// Labels
-	SalaryNotSpecified  string
-	SalaryFromLabel     string
-	SalaryToLabel       string
-	CompanyLabel        string
-	ContactLabel        string
-	TitleLabel          string
-	LevelLabel          string
-	TypeLabel           string
-	CategoryLabel       string
-	SalaryLabel         string
-	DescriptionLabel    string
-	ApplyLinkLabel      string
-	ExperienceLabel     string
-	EmploymentLabel     string
-	AboutLabel          string
-	ResumeLinkLabel     string
-	ExpectationsLabel   string
-	NotSpecifiedLabel   string
+	SalaryNotSpecified string
+	SalaryFromLabel    string
+	SalaryToLabel      string
+	CompanyLabel       string
+	ContactLabel       string
+	TitleLabel         string
+	LevelLabel         string
+	TypeLabel          string
+	CategoryLabel      string
+	SalaryLabel        string
+	DescriptionLabel   string
+	ApplyLinkLabel     string
+	ExperienceLabel    string
+	EmploymentLabel    string
+	AboutLabel         string
+	ResumeLinkLabel    string
+	ExpectationsLabel  string
+	NotSpecifiedLabel  string
 }
 
 var MessagesRU = Messages{
@@ -129,6 +197,10 @@ var MessagesRU = Messages{
 *Доступные команды:*
 • /post\_job — Разместить вакансию или резюме
 • /myjobs — Мои публикации и статусы
+• /balance — Пакеты публикаций
+• /search <запрос> — Поиск по публикациям
+• /subscribe <фильтры> — Подписаться на новые публикации
+• /subscriptions — Мои подписки
 • /pricing — Цены
 • /faq — Частые вопросы
 • /about — О сервисе
@@ -141,7 +213,19 @@ var MessagesRU = Messages{
 👮 *Админ-команды:*
 • /pending — Публикации на модерации
 • /stats — Статистика
-• /admins — Список админов`,
+• /admins — Список админов
+• /history <id> — История модерации публикации
+• /payment <id> <сумма> <валюта> <способ> [tier] — Записать оплату
+• /payments <id> — Оплаты публикации
+• /grant <telegram id> <кол-во> [дней] — Выдать пакет
+• /adjust <id пакета> <±кол-во> [дней] — Изменить пакет
+• /balance <telegram id> — Пакеты пользователя
+• /promo\_create <КОД> <20%|5.00> [tier=…] [uses=N] [days=N] — Создать промокод
+• /promo\_disable <КОД> — Отключить промокод
+• /promos — Список промокодов
+• /apikey\_create <telegram id> <название> — Выдать API-ключ
+• /apikey\_revoke <id ключа> — Отозвать API-ключ
+• /apikeys — Список API-ключей`,
 	UnknownCommand:     "Неизвестная команда. Используйте /help для справки.",
 	LanguageSet:        "✅ Язык установлен: Русский 🇷🇺",
 	ChooseLanguage:     "🌐 Выберите язык:",
@@ -234,8 +318,89 @@ var MessagesRU = Messages{
 	Cancelled:            "Отменено. Используйте /post\\_job чтобы начать заново.",
 	InvalidNumber:        "Введите корректное число или 'skip' / 'скип':",
 	SalaryToLessThanFrom: "Максимальная сумма не может быть меньше минимальной. Введите корректное число:",
-	InvalidExperience:    "Введите число лет (например 2 или 1.5) или 'skip':",
+	InvalidExperience:    "Введите число лет от 0 до 99.9 (например 2 или 1.5) или 'skip':",
 	OnlyLinksAllowed:     "⚠️ Файлы не принимаются!\n\nОтправьте ссылку (Google Docs, Notion, LinkedIn) или нажмите 'Пропустить'.",
+	InvalidLink:          "⚠️ Это не похоже на ссылку. Отправьте ссылку http(s), Telegram (t.me/… или @username) или email:",
+
+	InvalidFields:    "⚠️ Исправьте поля и отправьте снова:",
+	FieldRequired:    "%s — обязательное поле",
+	FieldInvalid:     "%s — недопустимое значение",
+	FieldNegative:    "%s — не может быть отрицательным",
+	FieldBelowMin:    "%s — максимум меньше минимума",
+	FieldAboveMax:    "%s — слишком большое значение",
+	FieldInvalidLink: "%s — нужна ссылка http(s), Telegram (t.me/… или @username) или email",
+
+	// Draft reminders
+	DraftReminder:  "📝 *У вас есть незавершённый черновик*\n\nВы начали заполнять публикацию, но не закончили. Продолжить с того же места?\n\nЧерновик будет удалён через %d ч. без активности.",
+	DraftDiscarded: "🗑 Черновик удалён. Используйте /post\\_job чтобы начать заново.",
+	DraftNotFound:  "Черновик не найден или уже истёк. Используйте /post\\_job чтобы начать заново.",
+	CurrentValue:   "Текущее значение: %s",
+
+	// Editing submitted posts
+	PostUpdated:       "✅ *Публикация обновлена*\n\nОна по-прежнему на модерации — админ увидит новую версию.",
+	RevisionSubmitted: "✅ *Изменения отправлены на модерацию*\n\nПосле одобрения пост в канале обновится. До этого в канале остаётся текущая версия.",
+	CannotEditPost:    "⚠️ Эту публикацию нельзя изменить. Редактировать можно только посты на модерации или опубликованные.",
+	ChooseCloseReason: "🔒 Почему вы закрываете публикацию?",
+	PostClosed:        "🔒 Публикация *%s* закрыта и перенесена в архив.",
+	CannotClosePost:   "⚠️ Эту публикацию нельзя закрыть.",
+
+	// Prepaid packages
+	BalanceTitle:    "📦 *Ваши пакеты публикаций*",
+	NoPackages:      "У вас нет пакетов публикаций. Подробнее — /pricing.",
+	PackageLine:     "Осталось %d из %d публикаций, действует до: %s",
+	PackageNoExpiry: "без срока",
+
+	// Telegram Payments
+	ChoosePlacement:    "💳 *Оплатить размещение сейчас?*\n\nПосле оплаты вакансия попадёт к админу с отметкой «оплачено». Подробнее о тарифах — /pricing.",
+	InvoiceStandard:    "Стандартное размещение",
+	InvoiceFeatured:    "Featured размещение (закреп 48 ч)",
+	PaymentReceived:    "✅ *Оплата получена!* Вакансия отправлена админу с отметкой «оплачено».",
+	PaymentUnavailable: "⚠️ Оплата этой публикации сейчас недоступна. Админ свяжется с вами.",
+	PayLaterNote:       "Хорошо, админ свяжется с вами по поводу оплаты.",
+
+	// Promo codes
+	PromoCodeLabel:   "Промокод",
+	EnterPromoCode:   "🏷 Отправьте промокод сообщением.",
+	PromoCodeApplied: "✅ Промокод *%s* применён: скидка %s на размещение.",
+	PromoCodeInvalid: "⚠️ Промокод не найден, истёк или уже использован. Проверьте код или продолжите без него.",
+
+	// Job alerts
+	SubscribeHelp: `🔔 *Подписка на публикации*
+
+Укажите фильтры после команды — бот перешлёт каждую новую подходящую публикацию из канала.
+
+• тип: vacancy, resume
+• уровень: junior, middle, senior, internship
+• формат: remote, hybrid, onsite
+• категория: web2, web3, dev
+• число — минимальная зарплата в $
+• остальные слова — ключевые слова (хотя бы одно должно встретиться)
+
+Добавьте daily или weekly, чтобы получать одну сводку в день или в неделю вместо отдельных сообщений; для сводки фильтры необязательны.
+
+Пример: /subscribe vacancy senior remote 3000 golang
+Сводка: /subscribe weekly web3`,
+	SubscriptionSaved:   "✅ Подписка сохранена: %s\n\nСписок подписок — /subscriptions.",
+	SubscriptionLimit:   "⚠️ Слишком много подписок. Удалите лишние через /subscriptions.",
+	NoSubscriptions:     "У вас нет подписок. Используйте /subscribe, чтобы добавить.",
+	YourSubscriptions:   "🔔 *Ваши подписки:*",
+	SubscriptionDeleted: "🗑 Подписка удалена.",
+	AllPosts:            "все публикации",
+	FrequencyDaily:      "📬 ежедневная сводка",
+	FrequencyWeekly:     "📬 еженедельная сводка",
+
+	// Search
+	SearchTitle:     "🔎 *Поиск:* %s",
+	SearchAnything:  "все публикации",
+	SearchResults:   "Найдено: %d · страница %d из %d",
+	SearchNoResults: "Ничего не найдено. Попробуйте другие слова или сбросьте фильтры.",
+	SearchExpired:   "Поиск устарел. Отправьте /search ещё раз.",
+
+	// Digests
+	DigestDailyTitle:  "📬 *Сводка за день*: новых публикаций — %d",
+	DigestWeeklyTitle: "📬 *Сводка за неделю*: новых публикаций — %d",
+	DigestMore:        "…и ещё %d",
+	DigestFooter:      "Настроить подписки — /subscriptions.",
 
 	// Level buttons
 	LevelJunior:       "🌱 Junior",
@@ -262,24 +427,24 @@ var MessagesRU = Messages{
 	EmploymentFreelance: "💻 Фриланс",
 
 	// Labels
-	SalaryNotSpecified:  "Не указана",
-	SalaryFromLabel:     "От $%d",
-	SalaryToLabel:       "До $%d",
-	CompanyLabel:        "Компания",
-	ContactLabel:        "Контакт",
-	TitleLabel:          "Должность",
-	LevelLabel:          "Уровень",
-	TypeLabel:           "Формат",
-	CategoryLabel:       "Категория",
-	SalaryLabel:         "Зарплата",
-	DescriptionLabel:    "Описание",
-	ApplyLinkLabel:      "Откликнуться",
-	ExperienceLabel:     "Опыт",
-	EmploymentLabel:     "Занятость",
-	AboutLabel:          "О кандидате",
-	ResumeLinkLabel:     "Резюме",
-	ExpectationsLabel:   "Ожидания",
-	NotSpecifiedLabel:   "Не указано",
+	SalaryNotSpecified: "Не указана",
+	SalaryFromLabel:    "От $%d",
+	SalaryToLabel:      "До $%d",
+	CompanyLabel:       "Компания",
+	ContactLabel:       "Контакт",
+	TitleLabel:         "Должность",
+	LevelLabel:         "Уровень",
+	TypeLabel:          "Формат",
+	CategoryLabel:      "Категория",
+	SalaryLabel:        "Зарплата",
+	DescriptionLabel:   "Описание",
+	ApplyLinkLabel:     "Откликнуться",
+	ExperienceLabel:    "Опыт",
+	EmploymentLabel:    "Занятость",
+	AboutLabel:         "О кандидате",
+	ResumeLinkLabel:    "Резюме",
+	ExpectationsLabel:  "Ожидания",
+	NotSpecifiedLabel:  "Не указано",
 }
 
 var MessagesEN = Messages{
@@ -300,6 +465,10 @@ You can change language anytime with /language.`,
 *Available commands:*
 • /post\_job — Post a job or resume
 • /myjobs — My posts & statuses
+• /balance — Post packages
+• /search <query> — Search posts
+• /subscribe <filters> — Get alerts about new posts
+• /subscriptions — My alerts
 • /pricing — Pricing
 • /faq — Frequently asked questions
 • /about — About the service
@@ -312,7 +481,19 @@ If you have any questions — use /faq or /contact.`,
 👮 *Admin commands:*
 • /pending — Posts awaiting moderation
 • /stats — Statistics
-• /admins — List of admins`,
+• /admins — List of admins
+• /history <id> — Moderation history of a post
+• /payment <id> <amount> <currency> <method> [tier] — Record a payment
+• /payments <id> — Payments of a post
+• /grant <telegram id> <credits> [days] — Grant a package
+• /adjust <package id> <±credits> [days] — Adjust a package
+• /balance <telegram id> — Packages of a user
+• /promo\_create <CODE> <20%|5.00> [tier=…] [uses=N] [days=N] — Create a promo code
+• /promo\_disable <CODE> — Disable a promo code
+• /promos — List promo codes
+• /apikey\_create <telegram id> <name> — Issue an API key
+• /apikey\_revoke <key id> — Revoke an API key
+• /apikeys — List API keys`,
 	UnknownCommand:     "Unknown command. Use /help for help.",
 	LanguageSet:        "✅ Language set to: English 🇬🇧",
 	ChooseLanguage:     "🌐 Choose language:",
@@ -405,8 +586,89 @@ For questions and partnerships:
 	Cancelled:            "Cancelled. Use /post\\_job to start again.",
 	InvalidNumber:        "Enter a valid number or 'skip':",
 	SalaryToLessThanFrom: "Maximum cannot be less than minimum. Enter a valid number:",
-	InvalidExperience:    "Enter years of experience (e.g. 2 or 1.5) or 'skip':",
+	InvalidExperience:    "Enter years of experience from 0 to 99.9 (e.g. 2 or 1.5) or 'skip':",
 	OnlyLinksAllowed:     "⚠️ Files are not accepted!\n\nSend a link (Google Docs, Notion, LinkedIn) or press 'Skip'.",
+	InvalidLink:          "⚠️ That doesn't look like a link. Send an http(s) link, a Telegram handle (t.me/… or @username) or an email:",
+
+	InvalidFields:    "⚠️ Fix these fields and submit again:",
+	FieldRequired:    "%s is required",
+	FieldInvalid:     "%s has an unknown value",
+	FieldNegative:    "%s can't be negative",
+	FieldBelowMin:    "%s: maximum is below minimum",
+	FieldAboveMax:    "%s is too large",
+	FieldInvalidLink: "%s must be an http(s) link, a Telegram handle (t.me/… or @username) or an email",
+
+	// Draft reminders
+	DraftReminder:  "📝 *You have an unfinished draft*\n\nYou started a post but didn't finish it. Continue where you left off?\n\nThe draft will be deleted after %d h of inactivity.",
+	DraftDiscarded: "🗑 Draft discarded. Use /post\\_job to start again.",
+	DraftNotFound:  "Draft not found or already expired. Use /post\\_job to start again.",
+	CurrentValue:   "Current value: %s",
+
+	// Editing submitted posts
+	PostUpdated:       "✅ *Post updated*\n\nIt is still awaiting moderation — the admin will see the new version.",
+	RevisionSubmitted: "✅ *Changes sent for moderation*\n\nOnce approved, the channel post will be updated. Until then the current version stays in the channel.",
+	CannotEditPost:    "⚠️ This post can't be edited. Only posts awaiting moderation or published posts can be changed.",
+	ChooseCloseReason: "🔒 Why are you closing this post?",
+	PostClosed:        "🔒 Post *%s* has been closed and archived.",
+	CannotClosePost:   "⚠️ This post can't be closed.",
+
+	// Prepaid packages
+	BalanceTitle:    "📦 *Your post packages*",
+	NoPackages:      "You have no post packages. See /pricing for details.",
+	PackageLine:     "%d of %d posts left, valid until: %s",
+	PackageNoExpiry: "no expiry",
+
+	// Telegram Payments
+	ChoosePlacement:    "💳 *Pay for the placement now?*\n\nOnce paid, your vacancy reaches the admin marked as paid. See /pricing for the plans.",
+	InvoiceStandard:    "Standard placement",
+	InvoiceFeatured:    "Featured placement (pinned for 48 h)",
+	PaymentReceived:    "✅ *Payment received!* Your vacancy was sent to the admin marked as paid.",
+	PaymentUnavailable: "⚠️ Payment for this post is not available right now. The admin will contact you.",
+	PayLaterNote:       "OK, the admin will contact you about the payment.",
+
+	// Promo codes
+	PromoCodeLabel:   "Promo code",
+	EnterPromoCode:   "🏷 Send your promo code as a message.",
+	PromoCodeApplied: "✅ Promo code *%s* applied: %s off the placement.",
+	PromoCodeInvalid: "⚠️ This promo code doesn't exist, has expired or was used up. Check the code or continue without it.",
+
+	// Job alerts
+	SubscribeHelp: `🔔 *Job alerts*
+
+Add filters after the command — the bot will forward every new matching post from the channel.
+
+• type: vacancy, resume
+• level: junior, middle, senior, internship
+• format: remote, hybrid, onsite
+• category: web2, web3, dev
+• a number — minimum salary in $
+• other words — keywords (at least one must match)
+
+Add daily or weekly to get one digest a day or a week instead of separate messages; filters are optional for digests.
+
+Example: /subscribe vacancy senior remote 3000 golang
+Digest: /subscribe weekly web3`,
+	SubscriptionSaved:   "✅ Alert saved: %s\n\nSee all alerts with /subscriptions.",
+	SubscriptionLimit:   "⚠️ Too many alerts. Delete some with /subscriptions.",
+	NoSubscriptions:     "You have no alerts. Use /subscribe to add one.",
+	YourSubscriptions:   "🔔 *Your alerts:*",
+	SubscriptionDeleted: "🗑 Alert deleted.",
+	AllPosts:            "all posts",
+	FrequencyDaily:      "📬 daily digest",
+	FrequencyWeekly:     "📬 weekly digest",
+
+	// Search
+	SearchTitle:     "🔎 *Search:* %s",
+	SearchAnything:  "all posts",
+	SearchResults:   "Found: %d · page %d of %d",
+	SearchNoResults: "Nothing found. Try other words or reset the filters.",
+	SearchExpired:   "This search has expired. Send /search again.",
+
+	// Digests
+	DigestDailyTitle:  "📬 *Daily digest*: %d new posts",
+	DigestWeeklyTitle: "📬 *Weekly digest*: %d new posts",
+	DigestMore:        "…and %d more",
+	DigestFooter:      "Manage your alerts with /subscriptions.",
 
 	// Level buttons
 	LevelJunior:       "🌱 Junior",
@@ -433,24 +695,24 @@ For questions and partnerships:
 	EmploymentFreelance: "💻 Freelance",
 
 	// Labels
-	SalaryNotSpecified:  "Not specified",
-	SalaryFromLabel:     "From $%d",
-	SalaryToLabel:       "Up to $%d",
-	CompanyLabel:        "Company",
-	ContactLabel:        "Contact",
-	TitleLabel:          "Position",
-	LevelLabel:          "Level",
-	TypeLabel:           "Format",
-	CategoryLabel:       "Category",
-	SalaryLabel:         "Salary",
-	DescriptionLabel:    "Description",
-	ApplyLinkLabel:      "Apply",
-	ExperienceLabel:     "Experience",
-	EmploymentLabel:     "Employment",
-	AboutLabel:          "About",
-	ResumeLinkLabel:     "Resume",
-	ExpectationsLabel:   "Expectations",
-	NotSpecifiedLabel:   "Not specified",
+	SalaryNotSpecified: "Not specified",
+	SalaryFromLabel:    "From $%d",
+	SalaryToLabel:      "Up to $%d",
+	CompanyLabel:       "Company",
+	ContactLabel:       "Contact",
+	TitleLabel:         "Position",
+	LevelLabel:         "Level",
+	TypeLabel:          "Format",
+	CategoryLabel:      "Category",
+	SalaryLabel:        "Salary",
+	DescriptionLabel:   "Description",
+	ApplyLinkLabel:     "Apply",
+	ExperienceLabel:    "Experience",
+	EmploymentLabel:    "Employment",
+	AboutLabel:         "About",
+	ResumeLinkLabel:    "Resume",
+	ExpectationsLabel:  "Expectations",
+	NotSpecifiedLabel:  "Not specified",
 }
 
 func GetMessages(lang Language) Messages {
@@ -467,9 +729,57 @@ var ButtonLabels = struct {
 	Resume  string
 
 	// Actions
-	Submit string
-	Cancel string
-	Skip   string
+	Submit      string
+	Cancel      string
+	Skip        string
+	Continue    string
+	Discard     string
+	Back        string
+	KeepCurrent string
+	EditPost    string
+	ClosePost   string
+
+	// Telegram Payments
+	PayStandard string
+	PayFeatured string
+	PayLater    string
+
+	// Promo codes
+	PromoCode       string
+	RemovePromoCode string
+
+	// Job alerts
+	DeleteSubscription string
+
+	// Search
+	SearchType     string
+	SearchLevel    string
+	SearchCategory string
+	SearchSalary   string
+	SearchAny      string
+	PrevPage       string
+	NextPage       string
+	OpenInChannel  string
+
+	// Close reasons
+	CloseFilled     string
+	CloseIrrelevant string
+	CloseOther      string
+
+	// Preview field editing
+	EditCompany     string
+	EditContact     string
+	EditTitle       string
+	EditLevel       string
+	EditType        string
+	EditCategory    string
+	EditDescription string
+	EditSalary      string
+	EditApplyLink   string
+	EditExperience  string
+	EditEmployment  string
+	EditAbout       string
+	EditResumeLink  string
 
 	// Levels
 	Junior     string
@@ -503,9 +813,57 @@ var ButtonLabels = struct {
 	Resume:  "👤 Resume",
 
 	// Actions
-	Submit: "✅ Submit",
-	Cancel: "❌ Cancel",
-	Skip:   "⏭️ Skip",
+	Submit:      "✅ Submit",
+	Cancel:      "❌ Cancel",
+	Skip:        "⏭️ Skip",
+	Continue:    "▶️ Continue",
+	Discard:     "🗑 Discard",
+	Back:        "⬅️ Back",
+	KeepCurrent: "✅ Keep current",
+	EditPost:    "✏️ Edit #%d",
+	ClosePost:   "🔒 Close #%d",
+
+	// Telegram Payments
+	PayStandard: "🟢 Standard — %s",
+	PayFeatured: "⭐ Featured — %s",
+	PayLater:    "⏳ Pay later",
+
+	// Promo codes
+	PromoCode:       "🏷 Promo code",
+	RemovePromoCode: "🗑 Remove promo code",
+
+	// Job alerts
+	DeleteSubscription: "🗑 Delete #%d",
+
+	// Search
+	SearchType:     "Format: %s",
+	SearchLevel:    "Level: %s",
+	SearchCategory: "Category: %s",
+	SearchSalary:   "Salary: %s",
+	SearchAny:      "any",
+	PrevPage:       "◀️ Prev",
+	NextPage:       "Next ▶️",
+	OpenInChannel:  "📢 Open in channel",
+
+	// Close reasons
+	CloseFilled:     "✅ Position filled / found a job",
+	CloseIrrelevant: "🚫 No longer relevant",
+	CloseOther:      "💬 Other",
+
+	// Preview field editing
+	EditCompany:     "✏️ Company",
+	EditContact:     "✏️ Contact",
+	EditTitle:       "✏️ Position",
+	EditLevel:       "✏️ Level",
+	EditType:        "✏️ Format",
+	EditCategory:    "✏️ Category",
+	EditDescription: "✏️ Description",
+	EditSalary:      "✏️ Salary",
+	EditApplyLink:   "✏️ Apply link",
+	EditExperience:  "✏️ Experience",
+	EditEmployment:  "✏️ Employment",
+	EditAbout:       "✏️ About",
+	EditResumeLink:  "✏️ Resume link",
 
 	// Levels
 	Junior:     "🌱 Junior",
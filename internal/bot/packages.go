@@ -0,0 +1,177 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+// cmdBalance shows the caller's prepaid packages. Admins can pass a telegram ID.
+func (b *Bot) cmdBalance(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	telegramID := msg.From.ID
+	if arg := strings.TrimSpace(msg.CommandArguments()); arg != "" {
+		if !b.cfg.IsAdmin(msg.From.ID) {
+			b.sendMessage(msg.Chat.ID, m.NoPermission)
+			return
+		}
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			b.sendMessage(msg.Chat.ID, "Usage: /balance [telegram id]")
+			return
+		}
+		telegramID = id
+	}
+
+	packages, err := b.packageService.GetBalance(context.Background(), telegramID)
+	if err != nil {
+		log.Printf("Error getting packages of %d: %v", telegramID, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	if len(packages) == 0 {
+		b.sendMessage(msg.Chat.ID, m.NoPackages)
+		return
+	}
+
+	now := time.Now()
+	text := m.BalanceTitle + "\n"
+	for _, pkg := range packages {
+		until := m.PackageNoExpiry
+		if pkg.ExpiresAt != nil {
+			until = pkg.ExpiresAt.Format("2006-01-02")
+		}
+		marker := "📦"
+		if !pkg.Active(now) {
+			marker = "▫️"
+		}
+		text += "\n" + marker + " " + fmt.Sprintf(m.PackageLine, pkg.Remaining(), pkg.TotalCredits, until)
+		if b.cfg.IsAdmin(msg.From.ID) {
+			text += fmt.Sprintf("\n   `%s`", pkg.ID)
+		}
+	}
+
+	b.sendMessage(msg.Chat.ID, text)
+}
+
+// cmdGrant gives a user a prepaid package: /grant <telegram id> <credits> [days]
+func (b *Bot) cmdGrant(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	const usage = "Usage: /grant <telegram id> <credits> [days]"
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) < 2 || len(args) > 3 {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	telegramID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	credits, err := strconv.Atoi(args[1])
+	if err != nil || credits <= 0 {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	var expiresAt *time.Time
+	if len(args) == 3 {
+		if expiresAt = parseValidDays(args[2]); expiresAt == nil {
+			b.sendMessage(msg.Chat.ID, usage)
+			return
+		}
+	}
+
+	pkg, err := b.packageService.Grant(context.Background(), msg.From.ID, telegramID, credits, expiresAt)
+	if err != nil {
+		log.Printf("Error granting package to %d: %v", telegramID, err)
+		b.sendMessage(msg.Chat.ID, "Failed to grant package: "+err.Error())
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, "📦 Package granted\n\n"+formatPackage(pkg))
+}
+
+// cmdAdjust changes a package: /adjust <package id> <±credits> [days]
+func (b *Bot) cmdAdjust(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	const usage = "Usage: /adjust <package id> <±credits> [days]\nExample: /adjust 1b9d…e4 +2 30"
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) < 2 || len(args) > 3 {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	packageID, err := uuid.Parse(args[0])
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	delta, err := strconv.Atoi(args[1])
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, usage)
+		return
+	}
+	var expiresAt *time.Time
+	if len(args) == 3 {
+		if expiresAt = parseValidDays(args[2]); expiresAt == nil {
+			b.sendMessage(msg.Chat.ID, usage)
+			return
+		}
+	}
+
+	pkg, err := b.packageService.Adjust(context.Background(), msg.From.ID, packageID, delta, expiresAt)
+	switch err {
+	case nil:
+	case service.ErrNotFound:
+		b.sendMessage(msg.Chat.ID, "Package not found")
+		return
+	case service.ErrInvalidPackage:
+		b.sendMessage(msg.Chat.ID, "Can't remove credits that were already used")
+		return
+	default:
+		log.Printf("Error adjusting package %s: %v", packageID, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, "📦 Package updated\n\n"+formatPackage(pkg))
+}
+
+func formatPackage(pkg *domain.Package) string {
+	until := "no expiry"
+	if pkg.ExpiresAt != nil {
+		until = "until " + pkg.ExpiresAt.Format("2006-01-02")
+	}
+	return fmt.Sprintf("`%s`\n%d of %d credits left, %s", pkg.ID, pkg.Remaining(), pkg.TotalCredits, until)
+}
+
+// parseValidDays turns a number of days into an expiry time from now
+func parseValidDays(s string) *time.Time {
+	days, err := strconv.Atoi(s)
+	if err != nil || days <= 0 {
+		return nil
+	}
+	expiresAt := time.Now().UTC().AddDate(0, 0, days)
+	return &expiresAt
+}
@@ -0,0 +1,210 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+const paymentUsage = "Usage: /payment <post id> <amount> <currency> <method> [standard|featured]\nExample: /payment 1b9d…e4 49.99 USD transfer featured"
+
+// cmdPayment records a pending payment against a post:
+// /payment <post id> <amount> <currency> <method> [tier]
+func (b *Bot) cmdPayment(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) < 4 || len(args) > 5 {
+		b.sendMessage(msg.Chat.ID, paymentUsage)
+		return
+	}
+
+	postID, err := uuid.Parse(args[0])
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, paymentUsage)
+		return
+	}
+	amount, ok := parseAmount(args[1])
+	if !ok {
+		b.sendMessage(msg.Chat.ID, "Invalid amount, use e.g. 49 or 49.99")
+		return
+	}
+
+	req := &domain.CreatePaymentRequest{
+		PostID:   &postID,
+		Amount:   amount,
+		Currency: args[2],
+		Method:   args[3],
+	}
+	if len(args) == 5 {
+		tier := domain.PlacementTier(strings.ToLower(args[4]))
+		req.Tier = &tier
+	}
+
+	payment, err := b.paymentService.RecordPayment(context.Background(), msg.From.ID, req)
+	switch err {
+	case nil:
+	case service.ErrNotFound:
+		b.sendMessage(msg.Chat.ID, "Post not found")
+		return
+	case service.ErrInvalidPayment:
+		b.sendMessage(msg.Chat.ID, paymentUsage)
+		return
+	default:
+		log.Printf("Error recording payment for post %s: %v", postID, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, "💳 Payment recorded\n\n"+formatPayment(payment))
+	reply.ParseMode = "Markdown"
+	reply.ReplyMarkup = paymentKeyboard(payment.ID.String())
+	if _, err := b.api.Send(reply); err != nil {
+		log.Printf("Error sending payment: %v", err)
+	}
+}
+
+// cmdPayments lists the payments of a post, pending ones with settle buttons
+func (b *Bot) cmdPayments(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	postID, err := uuid.Parse(strings.TrimSpace(msg.CommandArguments()))
+	if err != nil {
+		b.sendMessage(msg.Chat.ID, "Usage: /payments <post id>")
+		return
+	}
+
+	payments, err := b.paymentService.ListPostPayments(context.Background(), msg.From.ID, postID)
+	if err != nil {
+		log.Printf("Error listing payments of post %s: %v", postID, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	if len(payments) == 0 {
+		b.sendMessage(msg.Chat.ID, "No payments recorded for this post")
+		return
+	}
+
+	for _, payment := range payments {
+		reply := tgbotapi.NewMessage(msg.Chat.ID, formatPayment(&payment))
+		reply.ParseMode = "Markdown"
+		if payment.Status == domain.PaymentStatusPending {
+			reply.ReplyMarkup = paymentKeyboard(payment.ID.String())
+		}
+		if _, err := b.api.Send(reply); err != nil {
+			log.Printf("Error sending payment: %v", err)
+		}
+	}
+}
+
+// handlePaymentCallback settles a pending payment from its card
+func (b *Bot) handlePaymentCallback(callback *tgbotapi.CallbackQuery) {
+	data := callback.Data
+	adminID := callback.From.ID
+	chatID := callback.Message.Chat.ID
+	messageID := callback.Message.MessageID
+
+	if !b.cfg.IsAdmin(adminID) {
+		b.api.Request(tgbotapi.NewCallback(callback.ID, "You are not authorized"))
+		return
+	}
+
+	paid := strings.HasPrefix(data, "payment_paid:")
+	paymentID, err := uuid.Parse(strings.TrimPrefix(strings.TrimPrefix(data, "payment_paid:"), "payment_failed:"))
+	if err != nil {
+		b.sendMessage(chatID, "Invalid payment ID")
+		return
+	}
+
+	ctx := context.Background()
+	var payment *domain.Payment
+	if paid {
+		payment, err = b.paymentService.MarkPaid(ctx, adminID, paymentID)
+	} else {
+		payment, err = b.paymentService.MarkFailed(ctx, adminID, paymentID)
+	}
+	if err != nil && err != service.ErrInvalidTransition {
+		b.sendMessage(chatID, "Failed to update payment: "+err.Error())
+		return
+	}
+
+	// Already settled by another admin: just refresh the card
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, formatPayment(payment))
+	edit.ParseMode = "Markdown"
+	if _, err := b.api.Send(edit); err != nil {
+		log.Printf("Error editing payment message: %v", err)
+	}
+}
+
+func paymentKeyboard(paymentID string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Paid", "payment_paid:"+paymentID),
+			tgbotapi.NewInlineKeyboardButtonData("❌ Failed", "payment_failed:"+paymentID),
+		),
+	)
+}
+
+func formatPayment(p *domain.Payment) string {
+	text := fmt.Sprintf("`%s`\n*Amount:* %s %s\n*Method:* %s\n*Status:* %s",
+		p.ID, formatAmount(p.Amount), p.Currency, escapeMarkdown(p.Method), p.Status)
+	if p.Discount > 0 {
+		text += fmt.Sprintf("\n*Discount:* %s %s", formatAmount(p.Discount), p.Currency)
+	}
+	if p.Tier != nil {
+		text += fmt.Sprintf("\n*Tier:* %s", *p.Tier)
+	}
+	if p.PostID != nil {
+		text += fmt.Sprintf("\n*Post:* `%s`", *p.PostID)
+	}
+	if p.PaidAt != nil {
+		text += "\n*Paid at:* " + p.PaidAt.Format("2006-01-02 15:04")
+	}
+	return text
+}
+
+// parseAmount converts "49" or "49.99" into minor units
+func parseAmount(s string) (int, bool) {
+	s = strings.ReplaceAll(s, ",", ".")
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if hasFrac && (len(frac) == 0 || len(frac) > 2) {
+		return 0, false
+	}
+	units, err := strconv.Atoi(whole)
+	if err != nil || units < 0 {
+		return 0, false
+	}
+	cents := 0
+	if hasFrac {
+		if len(frac) == 1 {
+			frac += "0"
+		}
+		if cents, err = strconv.Atoi(frac); err != nil || cents < 0 {
+			return 0, false
+		}
+	}
+	amount := units*100 + cents
+	return amount, amount > 0
+}
+
+func formatAmount(amount int) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
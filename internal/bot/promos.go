@@ -0,0 +1,187 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+const promoCreateUsage = "Usage: /promo\\_create <CODE> <20%|5.00> [tier=standard|featured] [uses=N] [days=N]\nExample: /promo\\_create SPRING 20% tier=featured uses=50 days=30"
+
+// cmdPromoCreate adds a promo code: /promo_create <CODE> <20%|5.00> [tier=…] [uses=N] [days=N]
+func (b *Bot) cmdPromoCreate(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) < 2 {
+		b.sendMessage(msg.Chat.ID, promoCreateUsage)
+		return
+	}
+
+	promo := &domain.PromoCode{Code: args[0]}
+	if percent, ok := strings.CutSuffix(args[1], "%"); ok {
+		value, err := strconv.Atoi(percent)
+		if err != nil {
+			b.sendMessage(msg.Chat.ID, promoCreateUsage)
+			return
+		}
+		promo.Kind, promo.Value = domain.DiscountPercent, value
+	} else {
+		value, ok := parseAmount(args[1])
+		if !ok {
+			b.sendMessage(msg.Chat.ID, promoCreateUsage)
+			return
+		}
+		promo.Kind, promo.Value = domain.DiscountFixed, value
+	}
+
+	for _, arg := range args[2:] {
+		key, value, _ := strings.Cut(arg, "=")
+		switch key {
+		case "tier":
+			tier := domain.PlacementTier(strings.ToLower(value))
+			promo.Tier = &tier
+		case "uses":
+			uses, err := strconv.Atoi(value)
+			if err != nil {
+				b.sendMessage(msg.Chat.ID, promoCreateUsage)
+				return
+			}
+			promo.MaxUses = &uses
+		case "days":
+			if promo.ExpiresAt = parseValidDays(value); promo.ExpiresAt == nil {
+				b.sendMessage(msg.Chat.ID, promoCreateUsage)
+				return
+			}
+		default:
+			b.sendMessage(msg.Chat.ID, promoCreateUsage)
+			return
+		}
+	}
+
+	err := b.promoService.Create(context.Background(), msg.From.ID, promo)
+	switch err {
+	case nil:
+	case service.ErrInvalidPromoCode:
+		b.sendMessage(msg.Chat.ID, "Invalid promo code. Codes are 3–32 letters, digits, _ or -, percent discounts are 1–100%.\n\n"+promoCreateUsage)
+		return
+	case service.ErrPromoCodeExists:
+		b.sendMessage(msg.Chat.ID, "This code already exists")
+		return
+	default:
+		log.Printf("Error creating promo code %s: %v", promo.Code, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, "🏷 Promo code created\n\n"+b.formatPromoCode(promo))
+}
+
+// cmdPromoDisable stops a code from being used: /promo_disable <CODE>
+func (b *Bot) cmdPromoDisable(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	code := strings.TrimSpace(msg.CommandArguments())
+	if code == "" {
+		b.sendMessage(msg.Chat.ID, "Usage: /promo\\_disable <CODE>")
+		return
+	}
+
+	err := b.promoService.Disable(context.Background(), msg.From.ID, code)
+	if err == service.ErrNotFound {
+		b.sendMessage(msg.Chat.ID, "Promo code not found or already disabled")
+		return
+	}
+	if err != nil {
+		log.Printf("Error disabling promo code %s: %v", code, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, "🚫 Promo code *"+escapeMarkdown(service.NormalizePromoCode(code))+"* disabled")
+}
+
+// cmdPromos lists all promo codes
+func (b *Bot) cmdPromos(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	if !b.cfg.IsAdmin(msg.From.ID) {
+		b.sendMessage(msg.Chat.ID, m.NoPermission)
+		return
+	}
+
+	promos, err := b.promoService.List(context.Background(), msg.From.ID)
+	if err != nil {
+		log.Printf("Error listing promo codes: %v", err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	if len(promos) == 0 {
+		b.sendMessage(msg.Chat.ID, "No promo codes yet. Create one with /promo\\_create")
+		return
+	}
+
+	text := "🏷 *Promo codes*\n"
+	for _, promo := range promos {
+		text += "\n" + b.formatPromoCode(&promo) + "\n"
+	}
+	b.sendMessage(msg.Chat.ID, text)
+}
+
+// acceptPromoCode checks the code a recruiter typed on the preview and stores it in the draft
+func (b *Bot) acceptPromoCode(chatID int64, userID int64, text string, m Messages) {
+	promo, err := b.promoService.Validate(context.Background(), text)
+	if err != nil {
+		b.sendMessage(chatID, m.PromoCodeInvalid)
+		return
+	}
+
+	b.fsm.UpdateDraft(userID, func(d *PostDraft) { d.PromoCode = promo.Code })
+	b.sendMessage(chatID, fmt.Sprintf(m.PromoCodeApplied, escapeMarkdown(promo.Code), b.formatDiscount(promo)))
+	b.advance(chatID, userID, StatePreview)
+}
+
+func (b *Bot) formatPromoCode(promo *domain.PromoCode) string {
+	text := fmt.Sprintf("*%s* — %s off", escapeMarkdown(promo.Code), b.formatDiscount(promo))
+	if promo.Tier != nil {
+		text += fmt.Sprintf(", %s only", *promo.Tier)
+	}
+
+	uses := fmt.Sprintf("%d used", promo.UsedCount)
+	if promo.MaxUses != nil {
+		uses = fmt.Sprintf("%d of %d used", promo.UsedCount, *promo.MaxUses)
+	}
+	text += "\n   " + uses
+	if promo.ExpiresAt != nil {
+		text += ", until " + promo.ExpiresAt.Format("2006-01-02")
+	}
+	if !promo.Usable(time.Now()) {
+		text += " · inactive"
+	}
+	return text
+}
+
+func (b *Bot) formatDiscount(promo *domain.PromoCode) string {
+	if promo.Kind == domain.DiscountPercent {
+		return fmt.Sprintf("%d%%", promo.Value)
+	}
+	return formatAmount(promo.Value) + " " + b.cfg.PaymentCurrency
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PublishWorker drains the publish outbox: approved posts (including ones
+// scheduled for later) are sent to the channel and their authors notified
+type PublishWorker struct {
+	bot      *Bot
+	interval time.Duration
+}
+
+func NewPublishWorker(bot *Bot) *PublishWorker {
+	return &PublishWorker{
+		bot:      bot,
+		interval: 5 * time.Second,
+	}
+}
+
+func (w *PublishWorker) Start(ctx context.Context) {
+	log.Printf("Publish worker started. Checking every %s", w.interval)
+
+	w.publishDue(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Publish worker stopped")
+			return
+		case <-ticker.C:
+			w.publishDue(ctx)
+		}
+	}
+}
+
+func (w *PublishWorker) publishDue(ctx context.Context) {
+	posts, err := w.bot.jobService.ProcessPublishQueue(ctx)
+	if err != nil {
+		log.Printf("Error processing publish queue: %v", err)
+	}
+	if len(posts) == 0 {
+		return
+	}
+
+	notifier := NewAdminNotifier(w.bot.api, w.bot.cfg.AdminTelegramIDs)
+	for _, post := range posts {
+		log.Printf("Published post %s: %s", post.ID, post.Title)
+		notifier.NotifyAuthor(post.AuthorTelegramID, true, post.Title, post.Language, post.PostType, "")
+	}
+}
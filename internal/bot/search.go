@@ -0,0 +1,219 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/publisher"
+)
+
+const searchPageSize = 5
+
+// Values the filter buttons cycle through; the first one (nil) means any
+var (
+	searchTypes      = []domain.JobType{domain.JobTypeRemote, domain.JobTypeHybrid, domain.JobTypeOnsite}
+	searchLevels     = []domain.JobLevel{domain.JobLevelJunior, domain.JobLevelMiddle, domain.JobLevelSenior, domain.JobLevelInternship}
+	searchCategories = []domain.JobCategory{domain.JobCategoryWeb2, domain.JobCategoryWeb3, domain.JobCategoryDev}
+	searchSalaries   = []int{1000, 2000, 3000, 5000}
+)
+
+// SearchSession is the last search of a user, so the filter and page
+// buttons can refine it. It is kept in the StateStore with the rest of the
+// user's state, so the buttons keep working after a restart and on any replica.
+type SearchSession struct {
+	Filter domain.SearchFilter `json:"filter"`
+	Page   int                 `json:"page"`
+}
+
+// cmdSearch runs a full-text search over published posts, e.g. /search golang backend
+func (b *Bot) cmdSearch(msg *tgbotapi.Message) {
+	session := SearchSession{Filter: parseSearchQuery(msg.CommandArguments())}
+	b.fsm.SetSearch(msg.From.ID, &session)
+
+	text, keyboard, err := b.renderSearch(msg.From.ID, session)
+	if err != nil {
+		log.Printf("Error searching posts: %v", err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, text)
+	reply.ParseMode = "Markdown"
+	reply.DisableWebPagePreview = true
+	reply.ReplyMarkup = keyboard
+	b.api.Send(reply)
+}
+
+// handleSearchCallback handles "search_f:<filter>" (cycle a filter) and
+// "search_p:<page>" (go to a page)
+func (b *Bot) handleSearchCallback(callback *tgbotapi.CallbackQuery) {
+	userID := callback.From.ID
+	chatID := callback.Message.Chat.ID
+
+	stored := b.fsm.GetState(userID).Search
+	if stored == nil {
+		b.sendMessage(chatID, b.getInterfaceMessages(userID).SearchExpired)
+		return
+	}
+	session := *stored
+
+	switch {
+	case strings.HasPrefix(callback.Data, "search_p:"):
+		page, err := strconv.Atoi(strings.TrimPrefix(callback.Data, "search_p:"))
+		if err != nil || page < 0 {
+			return
+		}
+		session.Page = page
+	case strings.HasPrefix(callback.Data, "search_f:"):
+		f := &session.Filter
+		switch strings.TrimPrefix(callback.Data, "search_f:") {
+		case "type":
+			f.Type = cycle(f.Type, searchTypes)
+		case "level":
+			f.Level = cycle(f.Level, searchLevels)
+		case "category":
+			f.Category = cycle(f.Category, searchCategories)
+		case "salary":
+			f.MinSalary = cycle(f.MinSalary, searchSalaries)
+		default:
+			return
+		}
+		session.Page = 0
+	}
+	b.fsm.SetSearch(userID, &session)
+
+	text, keyboard, err := b.renderSearch(userID, session)
+	if err != nil {
+		log.Printf("Error searching posts: %v", err)
+		return
+	}
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, callback.Message.MessageID, text, keyboard)
+	edit.ParseMode = "Markdown"
+	edit.DisableWebPagePreview = true
+	b.api.Send(edit)
+}
+
+// renderSearch runs the search of a session and builds the results page with its buttons
+func (b *Bot) renderSearch(userID int64, session SearchSession) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	m := b.getInterfaceMessages(userID)
+
+	posts, total, err := b.jobService.Search(context.Background(), session.Filter, searchPageSize, session.Page*searchPageSize)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	query := m.SearchAnything
+	if session.Filter.Query != "" {
+		query = "«" + escapeMarkdown(session.Filter.Query) + "»"
+	}
+	text := fmt.Sprintf(m.SearchTitle, query)
+
+	pages := (total + searchPageSize - 1) / searchPageSize
+	if total == 0 {
+		text += "\n\n" + m.SearchNoResults
+	} else {
+		text += "\n" + fmt.Sprintf(m.SearchResults, total, session.Page+1, pages) + "\n"
+		for i, post := range posts {
+			line := publisher.FormatPostLine(&post, b.cfg.ChannelPostLink(*post.ChannelMessageID), m.SalaryFromLabel)
+			text += fmt.Sprintf("\n%d. %s", session.Page*searchPageSize+i+1, line)
+		}
+	}
+
+	f := session.Filter
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.SearchType, filterLabel(f.Type)), "search_f:type"),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.SearchLevel, filterLabel(f.Level)), "search_f:level"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.SearchCategory, filterLabel(f.Category)), "search_f:category"),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.SearchSalary, salaryFilterLabel(f.MinSalary)), "search_f:salary"),
+		),
+	}
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if session.Page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.PrevPage, fmt.Sprintf("search_p:%d", session.Page-1)))
+	}
+	if session.Page+1 < pages {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(ButtonLabels.NextPage, fmt.Sprintf("search_p:%d", session.Page+1)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	return text, tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
+
+// parseSearchQuery picks known post types, formats, levels, categories and
+// salary floors ("$3000", "3000+") out of a query; the other words are
+// searched as text
+func parseSearchQuery(text string) domain.SearchFilter {
+	var filter domain.SearchFilter
+	var words []string
+	for _, word := range strings.Fields(text) {
+		lower := strings.ToLower(word)
+
+		if strings.HasPrefix(lower, "$") || strings.HasSuffix(lower, "+") {
+			if salary, err := strconv.Atoi(strings.Trim(lower, "$+")); err == nil && salary > 0 {
+				filter.MinSalary = &salary
+				continue
+			}
+		}
+
+		switch v := domain.PostType(lower); v {
+		case domain.PostTypeVacancy, domain.PostTypeResume:
+			filter.PostType = &v
+			continue
+		}
+		if level := domain.JobLevel(lower); level != domain.JobLevelSkip && level.Valid() {
+			filter.Level = &level
+			continue
+		}
+		if jobType := domain.JobType(lower); jobType.Valid() {
+			filter.Type = &jobType
+			continue
+		}
+		if category := domain.JobCategory(lower); category.Valid() {
+			filter.Category = &category
+			continue
+		}
+
+		words = append(words, word)
+	}
+	filter.Query = strings.Join(words, " ")
+	return filter
+}
+
+// cycle moves a filter to its next value, wrapping from the last one back to any (nil)
+func cycle[T comparable](current *T, values []T) *T {
+	if current == nil {
+		return &values[0]
+	}
+	for i, v := range values {
+		if v == *current && i+1 < len(values) {
+			return &values[i+1]
+		}
+	}
+	return nil
+}
+
+func filterLabel[T ~string](v *T) string {
+	if v == nil {
+		return ButtonLabels.SearchAny
+	}
+	return string(*v)
+}
+
+func salaryFilterLabel(v *int) string {
+	if v == nil {
+		return ButtonLabels.SearchAny
+	}
+	return fmt.Sprintf("$%d+", *v)
+}
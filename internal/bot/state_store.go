@@ -0,0 +1,220 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"telegram-job/internal/repository"
+)
+
+// StateStore persists FSM state between updates.
+// Load returns nil (and no error) when the user has no stored state.
+// Update is an atomic read-modify-write: fn gets the stored state (a zero
+// UserState if there is none) and its changes are saved only if fn and the
+// store succeed. Concurrent updates of one user are applied one after another.
+type StateStore interface {
+	Load(ctx context.Context, userID int64) (*UserState, error)
+	Update(ctx context.Context, userID int64, fn func(*UserState)) error
+	Delete(ctx context.Context, userID int64) error
+	// ListIdle returns states holding a draft whose last activity is before idleSince
+	ListIdle(ctx context.Context, idleSince time.Time) (map[int64]*UserState, error)
+	// ExpireDraft clears the draft, keeping the language and admin state, if
+	// the user has been inactive since idleSince, and reports whether it did
+	ExpireDraft(ctx context.Context, userID int64, idleSince time.Time) (bool, error)
+	// MarkReminded sets RemindedAt unless it is already set or the user has
+	// been active since idleSince, and reports whether this call set it
+	MarkReminded(ctx context.Context, userID int64, idleSince time.Time, at time.Time) (bool, error)
+}
+
+// MemoryStateStore keeps state in process memory. State is lost on restart.
+type MemoryStateStore struct {
+	mu     sync.RWMutex
+	states map[int64]UserState
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		states: make(map[int64]UserState),
+	}
+}
+
+func (s *MemoryStateStore) Load(ctx context.Context, userID int64) (*UserState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.states[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s *MemoryStateStore) Update(ctx context.Context, userID int64, fn func(*UserState)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.states[userID]
+	fn(&state)
+	s.states[userID] = state
+	return nil
+}
+
+func (s *MemoryStateStore) Delete(ctx context.Context, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.states, userID)
+	return nil
+}
+
+func (s *MemoryStateStore) ListIdle(ctx context.Context, idleSince time.Time) (map[int64]*UserState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[int64]*UserState)
+	for userID, state := range s.states {
+		if state.UpdatedAt.Before(idleSince) && state.hasDraft() {
+			state := state
+			result[userID] = &state
+		}
+	}
+	return result, nil
+}
+
+func (s *MemoryStateStore) ExpireDraft(ctx context.Context, userID int64, idleSince time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[userID]
+	if !ok || !state.UpdatedAt.Before(idleSince) || !state.hasDraft() {
+		return false, nil
+	}
+	state.State = StateNone
+	state.Draft = PostDraft{}
+	state.Editing = false
+	state.RemindedAt = nil
+	s.states[userID] = state
+	return true, nil
+}
+
+func (s *MemoryStateStore) MarkReminded(ctx context.Context, userID int64, idleSince time.Time, at time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[userID]
+	if !ok || state.RemindedAt != nil || !state.UpdatedAt.Before(idleSince) {
+		return false, nil
+	}
+	state.RemindedAt = &at
+	s.states[userID] = state
+	return true, nil
+}
+
+// PostgresStateStore keeps state in the bot_fsm_states table so drafts
+// survive restarts and are shared between bot replicas.
+type PostgresStateStore struct {
+	repo *repository.FSMStateRepository
+}
+
+func NewPostgresStateStore(repo *repository.FSMStateRepository) *PostgresStateStore {
+	return &PostgresStateStore{repo: repo}
+}
+
+func (s *PostgresStateStore) Load(ctx context.Context, userID int64) (*UserState, error) {
+	record, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, nil
+	}
+	return stateFromRecord(record)
+}
+
+func (s *PostgresStateStore) Update(ctx context.Context, userID int64, fn func(*UserState)) error {
+	return s.repo.Update(ctx, userID, func(record *repository.FSMStateRecord) error {
+		state, err := stateFromRecord(record)
+		if err != nil {
+			return err
+		}
+		fn(state)
+
+		draft, err := json.Marshal(state.Draft)
+		if err != nil {
+			return err
+		}
+		var search []byte
+		if state.Search != nil {
+			if search, err = json.Marshal(state.Search); err != nil {
+				return err
+			}
+		}
+		*record = repository.FSMStateRecord{
+			TelegramID:   userID,
+			State:        int(state.State),
+			Language:     string(state.Language),
+			Draft:        draft,
+			Editing:      state.Editing,
+			RejectPostID: state.RejectPostID,
+			Search:       search,
+			CreatedAt:    state.CreatedAt,
+			UpdatedAt:    state.UpdatedAt,
+			RemindedAt:   state.RemindedAt,
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStateStore) Delete(ctx context.Context, userID int64) error {
+	return s.repo.Delete(ctx, userID)
+}
+
+func (s *PostgresStateStore) ListIdle(ctx context.Context, idleSince time.Time) (map[int64]*UserState, error) {
+	records, err := s.repo.ListUpdatedBefore(ctx, idleSince)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]*UserState, len(records))
+	for i := range records {
+		state, err := stateFromRecord(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		result[records[i].TelegramID] = state
+	}
+	return result, nil
+}
+
+func (s *PostgresStateStore) ExpireDraft(ctx context.Context, userID int64, idleSince time.Time) (bool, error) {
+	return s.repo.ExpireDraft(ctx, userID, idleSince)
+}
+
+func (s *PostgresStateStore) MarkReminded(ctx context.Context, userID int64, idleSince time.Time, at time.Time) (bool, error) {
+	return s.repo.MarkReminded(ctx, userID, idleSince, at)
+}
+
+func stateFromRecord(record *repository.FSMStateRecord) (*UserState, error) {
+	state := &UserState{
+		State:        State(record.State),
+		Language:     Language(record.Language),
+		Editing:      record.Editing,
+		RejectPostID: record.RejectPostID,
+		CreatedAt:    record.CreatedAt,
+		UpdatedAt:    record.UpdatedAt,
+		RemindedAt:   record.RemindedAt,
+	}
+	if len(record.Draft) > 0 {
+		if err := json.Unmarshal(record.Draft, &state.Draft); err != nil {
+			return nil, err
+		}
+	}
+	if len(record.Search) > 0 {
+		if err := json.Unmarshal(record.Search, &state.Search); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-job/internal/repository"
+	"telegram-job/internal/testutil"
+)
+
+// testStateStores runs fn against the in-memory store and, when a test
+// database is configured, the Postgres one
+func testStateStores(t *testing.T, fn func(t *testing.T, store StateStore)) {
+	t.Run("memory", func(t *testing.T) {
+		fn(t, NewMemoryStateStore())
+	})
+	t.Run("postgres", func(t *testing.T) {
+		db := testutil.OpenDB(t)
+		fn(t, NewPostgresStateStore(repository.NewFSMStateRepository(db)))
+	})
+}
+
+func setState(t *testing.T, store StateStore, userID int64, state UserState) {
+	t.Helper()
+	err := store.Update(context.Background(), userID, func(s *UserState) { *s = state })
+	if err != nil {
+		t.Fatalf("saving state of user %d: %v", userID, err)
+	}
+}
+
+func TestListIdleReturnsOnlyDrafts(t *testing.T) {
+	testStateStores(t, func(t *testing.T, store StateStore) {
+		now := time.Now().UTC()
+		idle := now.Add(-2 * time.Hour)
+
+		setState(t, store, 1, UserState{State: StateResumeWaitExperience, Draft: PostDraft{Title: "Go developer"}, Language: LangEN, CreatedAt: idle, UpdatedAt: idle})
+		setState(t, store, 2, UserState{Language: LangEN, CreatedAt: idle, UpdatedAt: idle})
+		setState(t, store, 3, UserState{State: StateResumeWaitExperience, Language: LangEN, CreatedAt: now, UpdatedAt: now})
+
+		states, err := store.ListIdle(context.Background(), now.Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("ListIdle: %v", err)
+		}
+		if len(states) != 1 || states[1] == nil {
+			t.Errorf("ListIdle returned users %v, want only user 1", keys(states))
+		}
+	})
+}
+
+func TestMarkRemindedSkipsReturnedUser(t *testing.T) {
+	testStateStores(t, func(t *testing.T, store StateStore) {
+		ctx := context.Background()
+		now := time.Now().UTC()
+		idleSince := now.Add(-time.Hour)
+		idle := now.Add(-2 * time.Hour)
+
+		// The user comes back after the worker listed them as idle
+		setState(t, store, 1, UserState{State: StateResumeWaitExperience, Language: LangEN, CreatedAt: idle, UpdatedAt: now})
+		ok, err := store.MarkReminded(ctx, 1, idleSince, now)
+		if err != nil {
+			t.Fatalf("MarkReminded: %v", err)
+		}
+		if ok {
+			t.Error("reminder marked for a user active since idleSince")
+		}
+
+		setState(t, store, 2, UserState{State: StateResumeWaitExperience, Language: LangEN, CreatedAt: idle, UpdatedAt: idle})
+		if ok, err := store.MarkReminded(ctx, 2, idleSince, now); err != nil || !ok {
+			t.Errorf("MarkReminded of an idle user = %v, %v, want true", ok, err)
+		}
+		if ok, err := store.MarkReminded(ctx, 2, idleSince, now); err != nil || ok {
+			t.Errorf("second MarkReminded = %v, %v, want false", ok, err)
+		}
+	})
+}
+
+func keys(states map[int64]*UserState) []int64 {
+	var ids []int64
+	for id := range states {
+		ids = append(ids, id)
+	}
+	return ids
+}
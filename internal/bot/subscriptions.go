@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+// cmdSubscribe saves a job alert from filter words, e.g.
+// /subscribe vacancy senior remote web3 3000 golang
+func (b *Bot) cmdSubscribe(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	args := msg.CommandArguments()
+	if strings.TrimSpace(args) == "" {
+		b.sendMessage(msg.Chat.ID, m.SubscribeHelp)
+		return
+	}
+
+	sub := parseSubscription(args)
+	err := b.subscriptionService.Subscribe(context.Background(), msg.From.ID, sub)
+	switch err {
+	case nil:
+	case service.ErrEmptySubscription:
+		b.sendMessage(msg.Chat.ID, m.SubscribeHelp)
+		return
+	case service.ErrTooManySubscriptions:
+		b.sendMessage(msg.Chat.ID, m.SubscriptionLimit)
+		return
+	default:
+		log.Printf("Error saving subscription of %d: %v", msg.From.ID, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	b.sendMessage(msg.Chat.ID, fmt.Sprintf(m.SubscriptionSaved, formatSubscription(sub, m)))
+}
+
+// cmdSubscriptions lists the user's job alerts with delete buttons
+func (b *Bot) cmdSubscriptions(msg *tgbotapi.Message) {
+	m := b.getInterfaceMessages(msg.From.ID)
+
+	subs, err := b.subscriptionService.List(context.Background(), msg.From.ID)
+	if err != nil {
+		log.Printf("Error listing subscriptions of %d: %v", msg.From.ID, err)
+		b.sendMessage(msg.Chat.ID, "Error / Ошибка")
+		return
+	}
+
+	if len(subs) == 0 {
+		b.sendMessage(msg.Chat.ID, m.NoSubscriptions)
+		return
+	}
+
+	text := m.YourSubscriptions + "\n"
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, sub := range subs {
+		text += fmt.Sprintf("\n%d. %s", i+1, formatSubscription(&sub, m))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(ButtonLabels.DeleteSubscription, i+1), "unsub:"+sub.ID.String()),
+		))
+	}
+
+	b.sendMessageWithKeyboard(msg.Chat.ID, text, tgbotapi.NewInlineKeyboardMarkup(rows...))
+}
+
+// unsubscribe handles "unsub:<id>" from /subscriptions
+func (b *Bot) unsubscribe(callback *tgbotapi.CallbackQuery) {
+	userID := callback.From.ID
+	chatID := callback.Message.Chat.ID
+	m := b.getInterfaceMessages(userID)
+
+	id, err := uuid.Parse(strings.TrimPrefix(callback.Data, "unsub:"))
+	if err != nil {
+		return
+	}
+
+	err = b.subscriptionService.Unsubscribe(context.Background(), userID, id)
+	if err != nil && err != service.ErrNotFound {
+		log.Printf("Error deleting subscription %s: %v", id, err)
+		b.sendMessage(chatID, "Error / Ошибка")
+		return
+	}
+
+	b.sendMessage(chatID, m.SubscriptionDeleted)
+}
+
+// parseSubscription reads filters from free-form words: a delivery frequency,
+// known post types, levels, formats and categories, a number as the minimum
+// salary, and anything else as a keyword
+func parseSubscription(args string) *domain.Subscription {
+	sub := &domain.Subscription{}
+	for _, word := range strings.Fields(strings.ReplaceAll(args, ",", " ")) {
+		lower := strings.ToLower(word)
+
+		if salary, err := strconv.Atoi(strings.Trim(lower, "$+")); err == nil && salary > 0 {
+			sub.MinSalary = &salary
+			continue
+		}
+
+		switch v := domain.AlertFrequency(lower); v {
+		case domain.AlertInstant, domain.AlertDaily, domain.AlertWeekly:
+			sub.Frequency = v
+			continue
+		}
+		switch v := domain.PostType(lower); v {
+		case domain.PostTypeVacancy, domain.PostTypeResume:
+			sub.PostType = &v
+			continue
+		}
+		if level := domain.JobLevel(lower); level != domain.JobLevelSkip && level.Valid() {
+			sub.Level = &level
+			continue
+		}
+		if jobType := domain.JobType(lower); jobType.Valid() {
+			sub.Type = &jobType
+			continue
+		}
+		switch v := domain.JobCategory(lower); v {
+		case domain.JobCategoryWeb2, domain.JobCategoryWeb3, domain.JobCategoryDev:
+			sub.Category = &v
+			continue
+		}
+
+		sub.Keywords = append(sub.Keywords, lower)
+	}
+	return sub
+}
+
+func formatSubscription(sub *domain.Subscription, m Messages) string {
+	var parts []string
+	if sub.PostType != nil {
+		parts = append(parts, string(*sub.PostType))
+	}
+	if sub.Level != nil {
+		parts = append(parts, string(*sub.Level))
+	}
+	if sub.Type != nil {
+		parts = append(parts, string(*sub.Type))
+	}
+	if sub.Category != nil {
+		parts = append(parts, string(*sub.Category))
+	}
+	if sub.MinSalary != nil {
+		parts = append(parts, fmt.Sprintf(m.SalaryFromLabel, *sub.MinSalary))
+	}
+	if len(sub.Keywords) > 0 {
+		parts = append(parts, "«"+escapeMarkdown(strings.Join(sub.Keywords, ", "))+"»")
+	}
+	if len(parts) == 0 {
+		parts = append(parts, m.AllPosts)
+	}
+
+	switch sub.Frequency {
+	case domain.AlertDaily:
+		parts = append(parts, m.FrequencyDaily)
+	case domain.AlertWeekly:
+		parts = append(parts, m.FrequencyWeekly)
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"telegram-job/internal/service"
+)
+
+// UnpinService unpins featured posts once their pin window is over
+type UnpinService struct {
+	jobService *service.JobService
+	interval   time.Duration
+}
+
+func NewUnpinService(jobService *service.JobService) *UnpinService {
+	return &UnpinService{
+		jobService: jobService,
+		interval:   5 * time.Minute,
+	}
+}
+
+func (u *UnpinService) Start(ctx context.Context) {
+	log.Printf("Unpin service started. Checking every %s", u.interval)
+
+	u.unpin(ctx)
+
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Unpin service stopped")
+			return
+		case <-ticker.C:
+			u.unpin(ctx)
+		}
+	}
+}
+
+func (u *UnpinService) unpin(ctx context.Context) {
+	posts, err := u.jobService.UnpinExpired(ctx)
+	if err != nil {
+		log.Printf("Error unpinning featured posts: %v", err)
+	}
+	for _, post := range posts {
+		log.Printf("Unpinned featured post %s: %s", post.ID, post.Title)
+	}
+}
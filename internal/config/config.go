@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -13,6 +14,25 @@ type Config struct {
 	APIPort          string
 	AdminTelegramIDs map[int64]bool
 	JobMaxDays       int
+	DraftTTLHours    int // Drafts idle longer than this are discarded
+	DraftRemindHours int // Idle drafts get one reminder after this many hours
+
+	ChannelPostsPerMinute int  // Cap on new channel posts per minute
+	FeaturedPinHours      int  // How long featured posts stay pinned
+	RequirePayment        bool // Block approval until a paid payment is linked to the post
+
+	// Telegram Payments: invoices are offered after submission if the token is set
+	PaymentProviderToken string
+	PaymentCurrency      string
+	PriceStandard        int // Minor units (cents)
+	PriceFeatured        int
+
+	// Digests go out at this hour (UTC): daily ones every day, weekly ones on Mondays
+	DigestHour          int
+	ChannelWeeklyDigest bool   // Also post a weekly digest to the channel
+	ChannelUsername     string // Public channel username for post links; empty uses t.me/c links
+
+	InitDataMaxAgeHours int // Mini App initData signed longer ago is rejected by the API
 }
 
 func Load() (*Config, error) {
@@ -35,6 +55,71 @@ func Load() (*Config, error) {
 		}
 	}
 
+	draftTTL := 72 // default
+	if hours := os.Getenv("DRAFT_TTL_HOURS"); hours != "" {
+		if h, err := strconv.Atoi(hours); err == nil {
+			draftTTL = h
+		}
+	}
+
+	draftRemind := 24 // default
+	if hours := os.Getenv("DRAFT_REMIND_HOURS"); hours != "" {
+		if h, err := strconv.Atoi(hours); err == nil {
+			draftRemind = h
+		}
+	}
+
+	postsPerMinute := 20 // default
+	if n := os.Getenv("CHANNEL_POSTS_PER_MINUTE"); n != "" {
+		if v, err := strconv.Atoi(n); err == nil {
+			postsPerMinute = v
+		}
+	}
+
+	pinHours := 48 // default
+	if hours := os.Getenv("FEATURED_PIN_HOURS"); hours != "" {
+		if h, err := strconv.Atoi(hours); err == nil {
+			pinHours = h
+		}
+	}
+
+	requirePayment, _ := strconv.ParseBool(os.Getenv("REQUIRE_PAYMENT")) // default false
+
+	currency := os.Getenv("PAYMENT_CURRENCY")
+	if currency == "" {
+		currency = "USD"
+	}
+
+	priceStandard := 2500 // default, see pricing.md
+	if p := os.Getenv("PRICE_STANDARD"); p != "" {
+		if v, err := strconv.Atoi(p); err == nil {
+			priceStandard = v
+		}
+	}
+
+	priceFeatured := 6500 // default
+	if p := os.Getenv("PRICE_FEATURED"); p != "" {
+		if v, err := strconv.Atoi(p); err == nil {
+			priceFeatured = v
+		}
+	}
+
+	digestHour := 9 // default
+	if h := os.Getenv("DIGEST_HOUR"); h != "" {
+		if v, err := strconv.Atoi(h); err == nil && v >= 0 && v < 24 {
+			digestHour = v
+		}
+	}
+
+	channelDigest, _ := strconv.ParseBool(os.Getenv("CHANNEL_WEEKLY_DIGEST")) // default false
+
+	initDataMaxAge := 24 // default
+	if hours := os.Getenv("INIT_DATA_MAX_AGE_HOURS"); hours != "" {
+		if h, err := strconv.Atoi(hours); err == nil {
+			initDataMaxAge = h
+		}
+	}
+
 	return &Config{
 		BotToken:         os.Getenv("BOT_TOKEN"),
 		ChannelID:        channelID,
@@ -42,6 +127,23 @@ func Load() (*Config, error) {
 		APIPort:          port,
 		AdminTelegramIDs: adminIDs,
 		JobMaxDays:       maxDays,
+		DraftTTLHours:    draftTTL,
+		DraftRemindHours: draftRemind,
+
+		ChannelPostsPerMinute: postsPerMinute,
+		FeaturedPinHours:      pinHours,
+		RequirePayment:        requirePayment,
+
+		PaymentProviderToken: os.Getenv("PAYMENT_PROVIDER_TOKEN"),
+		PaymentCurrency:      strings.ToUpper(currency),
+		PriceStandard:        priceStandard,
+		PriceFeatured:        priceFeatured,
+
+		DigestHour:          digestHour,
+		ChannelWeeklyDigest: channelDigest,
+		ChannelUsername:     strings.TrimPrefix(os.Getenv("CHANNEL_USERNAME"), "@"),
+
+		InitDataMaxAgeHours: initDataMaxAge,
 	}, nil
 }
 
@@ -62,3 +164,11 @@ func parseAdminIDs(s string) map[int64]bool {
 func (c *Config) IsAdmin(telegramID int64) bool {
 	return c.AdminTelegramIDs[telegramID]
 }
+
+// ChannelPostLink returns a t.me link to a channel message
+func (c *Config) ChannelPostLink(messageID int) string {
+	if c.ChannelUsername != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", c.ChannelUsername, messageID)
+	}
+	return fmt.Sprintf("https://t.me/c/%s/%d", strings.TrimPrefix(strconv.FormatInt(c.ChannelID, 10), "-100"), messageID)
+}
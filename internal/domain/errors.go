@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// RetryAfterError is returned when Telegram rate limits the bot and asks to
+// wait RetryAfter before the next request
+type RetryAfterError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RetryAfterError) Error() string { return e.Err.Error() }
+func (e *RetryAfterError) Unwrap() error { return e.Err }
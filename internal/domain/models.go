@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -51,6 +52,14 @@ const (
 	JobStatusArchived  JobStatus = "archived"
 )
 
+// PlacementTier is the paid placement chosen by the admin at approval
+type PlacementTier string
+
+const (
+	PlacementStandard PlacementTier = "standard"
+	PlacementFeatured PlacementTier = "featured" // Pinned in the channel for a while
+)
+
 // EmploymentType for resumes
 type EmploymentType string
 
@@ -105,6 +114,13 @@ type Post struct {
 	ChannelMessageID *int        `json:"channel_message_id,omitempty"`
 	PublishedAt      *time.Time  `json:"published_at,omitempty"`
 	CreatedAt        time.Time   `json:"created_at"`
+	StatusReason     string      `json:"status_reason,omitempty"` // Why the post was rejected or archived
+	PublishAt        *time.Time  `json:"publish_at,omitempty"`    // Scheduled publication time of an approved post
+	// Placement
+	Tier       PlacementTier `json:"tier"`
+	PinnedAt   *time.Time    `json:"pinned_at,omitempty"`
+	PinUntil   *time.Time    `json:"pin_until,omitempty"`
+	UnpinnedAt *time.Time    `json:"unpinned_at,omitempty"`
 	// Resume-specific fields
 	ExperienceYears *float64       `json:"experience_years,omitempty"`
 	Employment      EmploymentType `json:"employment,omitempty"`
@@ -122,11 +138,35 @@ type PostWithDetails struct {
 	CompanyName      string `json:"company_name,omitempty"`
 	CompanyContact   string `json:"company_contact,omitempty"`
 	AuthorTelegramID int64  `json:"author_telegram_id"`
+	Paid             bool   `json:"paid"` // A paid payment is linked to the post
+	PromoCode        string `json:"promo_code,omitempty"`
 }
 
 // JobWithCompany is alias for backward compatibility
 type JobWithCompany = PostWithDetails
 
+// PublishTask is a queued publication of an approved post to the channel
+type PublishTask struct {
+	ID               uuid.UUID
+	PostID           uuid.UUID
+	ActorTelegramID  *int64 // Admin who approved the post
+	Attempts         int
+	LastError        string
+	ChannelMessageID *int // Set once the post is in the channel
+	CreatedAt        time.Time
+}
+
+// PostEvent is an audit record of a post status transition
+type PostEvent struct {
+	ID              uuid.UUID `json:"id"`
+	PostID          uuid.UUID `json:"post_id"`
+	ActorTelegramID *int64    `json:"actor_telegram_id,omitempty"` // nil for system actions
+	FromStatus      JobStatus `json:"from_status"`
+	ToStatus        JobStatus `json:"to_status"`
+	Reason          string    `json:"reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
 // CreateJobRequest is used when creating a new vacancy
 type CreateJobRequest struct {
 	Company     string      `json:"company"`
@@ -140,23 +180,121 @@ type CreateJobRequest struct {
 	Description string      `json:"description"`
 	ApplyLink   string      `json:"apply_link"`
 	Language    string      `json:"language"`
+	PromoCode   string      `json:"promo_code,omitempty"`
 }
 
 // CreateResumeRequest is used when creating a new resume
 type CreateResumeRequest struct {
-	Title           string         `json:"title"`           // Position title
-	Level           JobLevel       `json:"level"`           // Experience level
-	Type            JobType        `json:"type"`            // Work format (remote/hybrid/onsite)
-	Employment      EmploymentType `json:"employment"`      // full-time, part-time, etc.
+	Title           string         `json:"title"`      // Position title
+	Level           JobLevel       `json:"level"`      // Experience level
+	Type            JobType        `json:"type"`       // Work format (remote/hybrid/onsite)
+	Employment      EmploymentType `json:"employment"` // full-time, part-time, etc.
 	SalaryFrom      *int           `json:"salary_from,omitempty"`
 	SalaryTo        *int           `json:"salary_to,omitempty"`
 	ExperienceYears *float64       `json:"experience_years,omitempty"`
-	About           string         `json:"about"`           // About the candidate
-	Contact         string         `json:"contact"`         // Contact info
-	ResumeLink      string         `json:"resume_link"`     // Link to CV (optional)
+	About           string         `json:"about"`       // About the candidate
+	Contact         string         `json:"contact"`     // Contact info
+	ResumeLink      string         `json:"resume_link"` // Link to CV (optional)
 	Language        string         `json:"language"`
 }
 
+// DraftContent is a vacancy or resume being filled in the Mini App. Nothing
+// is required until the draft is submitted.
+type DraftContent struct {
+	Title      string   `json:"title,omitempty"`
+	Level      JobLevel `json:"level,omitempty"`
+	Type       JobType  `json:"type,omitempty"`
+	SalaryFrom *int     `json:"salary_from,omitempty"`
+	SalaryTo   *int     `json:"salary_to,omitempty"`
+	Language   string   `json:"language,omitempty"`
+	Contact    string   `json:"contact,omitempty"` // Company contact or candidate contact
+	// Vacancy fields
+	Company     string      `json:"company,omitempty"`
+	Category    JobCategory `json:"category,omitempty"`
+	Description string      `json:"description,omitempty"`
+	ApplyLink   string      `json:"apply_link,omitempty"`
+	PromoCode   string      `json:"promo_code,omitempty"`
+	// Resume fields
+	Employment      EmploymentType `json:"employment,omitempty"`
+	ExperienceYears *float64       `json:"experience_years,omitempty"`
+	About           string         `json:"about,omitempty"`
+	ResumeLink      string         `json:"resume_link,omitempty"`
+}
+
+// JobRequest is the vacancy a submitted draft creates
+func (c *DraftContent) JobRequest() *CreateJobRequest {
+	return &CreateJobRequest{
+		Company:     c.Company,
+		Contact:     c.Contact,
+		Title:       c.Title,
+		Level:       c.Level,
+		Type:        c.Type,
+		Category:    c.Category,
+		SalaryFrom:  c.SalaryFrom,
+		SalaryTo:    c.SalaryTo,
+		Description: c.Description,
+		ApplyLink:   c.ApplyLink,
+		Language:    c.Language,
+		PromoCode:   c.PromoCode,
+	}
+}
+
+// ResumeRequest is the resume a submitted draft creates
+func (c *DraftContent) ResumeRequest() *CreateResumeRequest {
+	return &CreateResumeRequest{
+		Title:           c.Title,
+		Level:           c.Level,
+		Type:            c.Type,
+		Employment:      c.Employment,
+		SalaryFrom:      c.SalaryFrom,
+		SalaryTo:        c.SalaryTo,
+		ExperienceYears: c.ExperienceYears,
+		About:           c.About,
+		Contact:         c.Contact,
+		ResumeLink:      c.ResumeLink,
+		Language:        c.Language,
+	}
+}
+
+type Draft struct {
+	ID          uuid.UUID    `json:"id"`
+	TelegramID  int64        `json:"telegram_id"`
+	PostType    PostType     `json:"post_type"`
+	Content     DraftContent `json:"content"`
+	SubmittedAt *time.Time   `json:"submitted_at,omitempty"`
+	PostID      *uuid.UUID   `json:"post_id,omitempty"` // The post created on submit
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// Validate checks the draft as the request it creates on submit
+func (d *Draft) Validate() error {
+	if d.PostType == PostTypeResume {
+		return d.Content.ResumeRequest().Validate()
+	}
+	return d.Content.JobRequest().Validate()
+}
+
+type RevisionStatus string
+
+const (
+	RevisionStatusPending    RevisionStatus = "pending"
+	RevisionStatusApproved   RevisionStatus = "approved"
+	RevisionStatusRejected   RevisionStatus = "rejected"
+	RevisionStatusSuperseded RevisionStatus = "superseded" // Replaced by a newer edit before moderation
+)
+
+// PostRevision is an author's edit of a published post awaiting moderation.
+// Content holds the proposed version of the post.
+type PostRevision struct {
+	ID         uuid.UUID       `json:"id"`
+	PostID     uuid.UUID       `json:"post_id"`
+	Status     RevisionStatus  `json:"status"`
+	Content    PostWithDetails `json:"content"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ReviewedAt *time.Time      `json:"reviewed_at,omitempty"`
+}
+
 // Stats contains job statistics
 type Stats struct {
 	Total     int `json:"total"`
@@ -165,3 +303,256 @@ type Stats struct {
 	Rejected  int `json:"rejected"`
 	Archived  int `json:"archived"`
 }
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending PaymentStatus = "pending"
+	PaymentStatusPaid    PaymentStatus = "paid"
+	PaymentStatusFailed  PaymentStatus = "failed"
+)
+
+type PaymentType string
+
+const (
+	PaymentTypeSingle       PaymentType = "single"
+	PaymentTypePackage      PaymentType = "package"
+	PaymentTypeSubscription PaymentType = "subscription"
+)
+
+// Payment is a ledger entry recorded by an admin against a post or a company.
+// Amount is in minor units (cents).
+type Payment struct {
+	ID          uuid.UUID      `json:"id"`
+	CompanyID   *uuid.UUID     `json:"company_id,omitempty"`
+	PostID      *uuid.UUID     `json:"post_id,omitempty"`
+	Amount      int            `json:"amount"`
+	Currency    string         `json:"currency"`
+	Method      string         `json:"method"`
+	Type        PaymentType    `json:"type"`
+	Tier        *PlacementTier `json:"tier,omitempty"`
+	Status      PaymentStatus  `json:"status"`
+	RecordedBy  *int64         `json:"recorded_by,omitempty"`
+	ExternalID  string         `json:"external_id,omitempty"` // Telegram payment charge ID
+	PromoCodeID *uuid.UUID     `json:"promo_code_id,omitempty"`
+	Discount    int            `json:"discount,omitempty"` // Minor units taken off the list price
+	PaidAt      *time.Time     `json:"paid_at,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type CreatePaymentRequest struct {
+	PostID    *uuid.UUID     `json:"post_id"`
+	CompanyID *uuid.UUID     `json:"company_id"`
+	Amount    int            `json:"amount"`
+	Currency  string         `json:"currency"`
+	Method    string         `json:"method"`
+	Type      PaymentType    `json:"type"`
+	Tier      *PlacementTier `json:"tier"`
+}
+
+// AlertFrequency is how matching posts are delivered to a subscriber
+type AlertFrequency string
+
+const (
+	AlertInstant AlertFrequency = "instant" // forwarded as soon as published
+	AlertDaily   AlertFrequency = "daily"   // one digest a day
+	AlertWeekly  AlertFrequency = "weekly"  // one digest a week
+)
+
+// Subscription is a saved filter of a job seeker. Empty fields match anything;
+// any one of the keywords has to occur in the post.
+type Subscription struct {
+	ID           uuid.UUID      `json:"id"`
+	TelegramID   int64          `json:"telegram_id"`
+	PostType     *PostType      `json:"post_type,omitempty"`
+	Level        *JobLevel      `json:"level,omitempty"`
+	Type         *JobType       `json:"type,omitempty"`
+	Category     *JobCategory   `json:"category,omitempty"`
+	MinSalary    *int           `json:"min_salary,omitempty"`
+	Keywords     []string       `json:"keywords"`
+	Frequency    AlertFrequency `json:"frequency"`
+	LastDigestAt *time.Time     `json:"last_digest_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// SearchFilter narrows a search over published posts; empty fields match anything
+type SearchFilter struct {
+	Query     string
+	PostType  *PostType
+	Type      *JobType
+	Level     *JobLevel
+	Category  *JobCategory
+	MinSalary *int
+}
+
+// PrincipalKind is how an API caller proved who they are
+type PrincipalKind string
+
+const (
+	PrincipalWebApp PrincipalKind = "webapp"  // signed Telegram Mini App initData
+	PrincipalAPIKey PrincipalKind = "api_key" // issued API key
+	PrincipalBot    PrincipalKind = "bot"     // sender of a bot update, verified by Telegram
+)
+
+// Principal is the verified caller of the HTTP API or the bot. Permission
+// checks use TelegramID; for API keys it is the user the key was issued for.
+type Principal struct {
+	Kind       PrincipalKind
+	TelegramID int64
+	Username   string
+	APIKeyID   *uuid.UUID
+}
+
+// APIKey is a server-to-server credential; only a hash of the key is stored
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	TelegramID int64      `json:"telegram_id"`
+	CreatedBy  int64      `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// PostSort is the order of the public post list
+type PostSort string
+
+const (
+	SortNewest PostSort = "newest"
+	SortOldest PostSort = "oldest"
+	SortSalary PostSort = "salary" // highest salary first
+)
+
+// PostListFilter selects published posts for the public API; empty fields match anything
+type PostListFilter struct {
+	PostType        *PostType
+	Level           *JobLevel
+	Type            *JobType
+	Category        *JobCategory
+	SalaryMin       *int
+	SalaryMax       *int
+	Language        string
+	PublishedAfter  *time.Time
+	PublishedBefore *time.Time
+	Sort            PostSort
+	Limit           int
+	After           *PostCursor // Continue after this post
+}
+
+// PostCursor is the position of the last post of a page in a given sort order
+type PostCursor struct {
+	Sort        PostSort  `json:"o"`
+	PublishedAt time.Time `json:"p"`
+	Salary      int       `json:"s,omitempty"`
+	ID          uuid.UUID `json:"i"`
+}
+
+// PublicPost is a published post as exposed to partner sites, without
+// moderation, payment and author details
+type PublicPost struct {
+	ID              uuid.UUID      `json:"id"`
+	PostType        PostType       `json:"post_type"`
+	Title           string         `json:"title"`
+	Level           JobLevel       `json:"level,omitempty"`
+	Type            JobType        `json:"type"`
+	Category        JobCategory    `json:"category"`
+	SalaryFrom      *int           `json:"salary_from,omitempty"`
+	SalaryTo        *int           `json:"salary_to,omitempty"`
+	Description     string         `json:"description,omitempty"`
+	ApplyLink       string         `json:"apply_link,omitempty"`
+	ExperienceYears *float64       `json:"experience_years,omitempty"`
+	Employment      EmploymentType `json:"employment,omitempty"`
+	About           string         `json:"about,omitempty"`
+	ResumeLink      string         `json:"resume_link,omitempty"`
+	Contact         string         `json:"contact,omitempty"`
+	Language        string         `json:"language"`
+	Company         *PublicCompany `json:"company,omitempty"`
+	Featured        bool           `json:"featured"`
+	PublishedAt     *time.Time     `json:"published_at"`
+	URL             string         `json:"url"` // Link to the channel message
+}
+
+// PublicCompany is the part of a company shown in the channel; the company
+// contact is private to the author and admins
+type PublicCompany struct {
+	Name string `json:"name"`
+}
+
+// JobAlert is a published post waiting to be forwarded to a subscriber
+type JobAlert struct {
+	ID         uuid.UUID
+	TelegramID int64
+	PostID     uuid.UUID
+}
+
+type DiscountKind string
+
+const (
+	DiscountPercent DiscountKind = "percent"
+	DiscountFixed   DiscountKind = "fixed"
+)
+
+// PromoCode is a discount a recruiter enters before submitting a vacancy.
+// Value is a percentage or, for fixed discounts, minor units.
+type PromoCode struct {
+	ID         uuid.UUID      `json:"id"`
+	Code       string         `json:"code"`
+	Kind       DiscountKind   `json:"kind"`
+	Value      int            `json:"value"`
+	Tier       *PlacementTier `json:"tier,omitempty"` // nil = any tier
+	MaxUses    *int           `json:"max_uses,omitempty"`
+	UsedCount  int            `json:"used_count"`
+	ExpiresAt  *time.Time     `json:"expires_at,omitempty"`
+	DisabledAt *time.Time     `json:"disabled_at,omitempty"`
+	CreatedBy  *int64         `json:"created_by,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// Usable reports whether the code can still be redeemed at the given time
+func (p *PromoCode) Usable(now time.Time) bool {
+	if p.DisabledAt != nil || (p.ExpiresAt != nil && !p.ExpiresAt.After(now)) {
+		return false
+	}
+	return p.MaxUses == nil || p.UsedCount < *p.MaxUses
+}
+
+func (p *PromoCode) AppliesTo(tier PlacementTier) bool {
+	return p.Tier == nil || *p.Tier == tier
+}
+
+// Discount returns how much is taken off amount, never more than amount
+func (p *PromoCode) Discount(amount int) int {
+	discount := p.Value
+	if p.Kind == DiscountPercent {
+		discount = amount * p.Value / 100
+	}
+	if discount > amount {
+		return amount
+	}
+	return discount
+}
+
+// Package is a prepaid bundle of standard posts. One credit is used
+// when one of the owner's posts is approved.
+type Package struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	TotalCredits int        `json:"total_credits"`
+	UsedCredits  int        `json:"used_credits"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	GrantedBy    *int64     `json:"granted_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ErrNoPackageCredit is returned when an approval relies on a prepaid package
+// that has no credit left by the time it is charged
+var ErrNoPackageCredit = errors.New("no package credit left")
+
+func (p *Package) Remaining() int {
+	return p.TotalCredits - p.UsedCredits
+}
+
+// Active reports whether the package can still be used at the given time
+func (p *Package) Active(now time.Time) bool {
+	return p.Remaining() > 0 && (p.ExpiresAt == nil || p.ExpiresAt.After(now))
+}
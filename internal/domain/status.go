@@ -0,0 +1,56 @@
+package domain
+
+import "errors"
+
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// Actor is who changes a post status. The same move may be allowed to one
+// actor and not another (an author may withdraw a pending post, an admin
+// rejects it instead).
+type Actor string
+
+const (
+	ActorAuthor Actor = "author"
+	ActorAdmin  Actor = "admin"
+	ActorSystem Actor = "system" // Publisher and background workers
+)
+
+type transition struct {
+	from  JobStatus
+	to    JobStatus
+	actor Actor
+}
+
+// allowedTransitions is the post status state machine (see api_contract.md)
+var allowedTransitions = map[transition]bool{
+	{JobStatusDraft, JobStatusPending, ActorAuthor}:      true,
+	{JobStatusPending, JobStatusApproved, ActorAdmin}:    true,
+	{JobStatusPending, JobStatusRejected, ActorAdmin}:    true,
+	{JobStatusPending, JobStatusArchived, ActorAuthor}:   true, // Withdrawn before moderation
+	{JobStatusApproved, JobStatusPublished, ActorSystem}: true,
+	{JobStatusPublished, JobStatusArchived, ActorAuthor}: true, // Closed by the author
+	{JobStatusPublished, JobStatusArchived, ActorAdmin}:  true, // Deleted by an admin
+	{JobStatusPublished, JobStatusArchived, ActorSystem}: true, // Expired
+}
+
+// StatusChange is a validated transition. Repositories apply it only if the
+// post is still in From, so concurrent changes can't both succeed.
+type StatusChange struct {
+	From JobStatus
+	To   JobStatus
+}
+
+// CanTransition reports whether actor may move a post from one status to another
+func CanTransition(from, to JobStatus, actor Actor) bool {
+	return allowedTransitions[transition{from, to, actor}]
+}
+
+// Transition validates actor moving post to status to and updates post.Status
+func Transition(post *Post, to JobStatus, actor Actor) (StatusChange, error) {
+	if !CanTransition(post.Status, to, actor) {
+		return StatusChange{}, ErrInvalidTransition
+	}
+	change := StatusChange{From: post.Status, To: to}
+	post.Status = to
+	return change, nil
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Codes of field errors, for callers that word the messages themselves
+const (
+	CodeRequired    = "required"
+	CodeInvalid     = "invalid"      // Not one of the allowed values
+	CodeNegative    = "negative"     // A number below zero
+	CodeBelowMin    = "below_min"    // salary_to below salary_from
+	CodeAboveMax    = "above_max"    // A number above what the field can hold
+	CodeInvalidLink = "invalid_link" // Not an http(s) URL, Telegram handle or email
+)
+
+// MaxExperienceYears is the largest experience_years that fits NUMERIC(3,1)
+const MaxExperienceYears = 99.9
+
+// FieldError is a problem with one input field
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every invalid field of a request, so a form can
+// show them all at once
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+" "+f.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns nil if no field was invalid, so callers can `return v.Err()`
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// FieldErrors returns the invalid fields reported by err, if it is a
+// ValidationError
+func FieldErrors(err error) []FieldError {
+	var v *ValidationError
+	if errors.As(err, &v) {
+		return v.Fields
+	}
+	return nil
+}
+
+// Valid reports whether the level is a known one; JobLevelSkip isn't
+func (l JobLevel) Valid() bool {
+	return l == JobLevelJunior || l == JobLevelMiddle || l == JobLevelSenior || l == JobLevelInternship
+}
+
+func (t JobType) Valid() bool {
+	return t == JobTypeRemote || t == JobTypeHybrid || t == JobTypeOnsite
+}
+
+func (c JobCategory) Valid() bool {
+	return c == JobCategoryWeb2 || c == JobCategoryWeb3 || c == JobCategoryDev
+}
+
+func (e EmploymentType) Valid() bool {
+	return e == EmploymentFullTime || e == EmploymentPartTime || e == EmploymentContract || e == EmploymentFreelance
+}
+
+var (
+	telegramUsername = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{3,31}$`)
+	telegramPath     = regexp.MustCompile(`^[A-Za-z0-9_+-]+(/[A-Za-z0-9_-]+)*/?$`)
+)
+
+// IsLink reports whether s is something readers can follow to reach the
+// author: an http(s) URL, a Telegram handle (t.me/name or @name) or an email
+func IsLink(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, " \t\r\n") {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(s, "@"):
+		return telegramUsername.MatchString(s[1:])
+	case strings.HasPrefix(s, "t.me/"):
+		return telegramPath.MatchString(strings.TrimPrefix(s, "t.me/"))
+	case strings.HasPrefix(s, "http://"), strings.HasPrefix(s, "https://"):
+		u, err := url.Parse(s)
+		return err == nil && u.Host != ""
+	}
+
+	// A bare address only, not "Name <address>"
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s && strings.Contains(s[strings.LastIndex(s, "@"):], ".")
+}
+
+// Validate checks a vacancy before it is created or changed; the level may
+// be left out. It returns a *ValidationError listing every invalid field.
+func (r *CreateJobRequest) Validate() error {
+	v := &ValidationError{}
+	v.required("company", r.Company)
+	v.required("contact", r.Contact)
+	v.required("title", r.Title)
+	v.level(r.Level)
+	v.jobType(r.Type)
+	if r.Category == "" {
+		v.Add("category", CodeRequired, "is required")
+	} else if !r.Category.Valid() {
+		v.Add("category", CodeInvalid, "must be web2, web3 or dev")
+	}
+	v.required("description", r.Description)
+	v.salary(r.SalaryFrom, r.SalaryTo)
+	if strings.TrimSpace(r.ApplyLink) == "" {
+		v.Add("apply_link", CodeRequired, "is required")
+	} else {
+		v.link("apply_link", r.ApplyLink)
+	}
+	v.language(r.Language)
+	return v.Err()
+}
+
+// ValidExperience reports whether years of experience can be stored: the value
+// is rounded to one decimal like the database does, so 99.96 doesn't fit
+func ValidExperience(years float64) bool {
+	return years >= 0 && math.Round(years*10)/10 <= MaxExperienceYears
+}
+
+// Validate checks a resume before it is created or changed; the level,
+// experience and link may be left out
+func (r *CreateResumeRequest) Validate() error {
+	v := &ValidationError{}
+	v.required("title", r.Title)
+	v.level(r.Level)
+	if r.ExperienceYears != nil {
+		if *r.ExperienceYears < 0 {
+			v.Add("experience_years", CodeNegative, "must not be negative")
+		} else if !ValidExperience(*r.ExperienceYears) {
+			v.Add("experience_years", CodeAboveMax, "must not be more than 99.9")
+		}
+	}
+	v.jobType(r.Type)
+	if r.Employment == "" {
+		v.Add("employment", CodeRequired, "is required")
+	} else if !r.Employment.Valid() {
+		v.Add("employment", CodeInvalid, "must be full-time, part-time, contract or freelance")
+	}
+	v.salary(r.SalaryFrom, r.SalaryTo)
+	v.required("about", r.About)
+	v.required("contact", r.Contact)
+	if r.ResumeLink != "" {
+		v.link("resume_link", r.ResumeLink)
+	}
+	v.language(r.Language)
+	return v.Err()
+}
+
+func (e *ValidationError) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		e.Add(field, CodeRequired, "is required")
+	}
+}
+
+// link checks a field readers follow, with the same rules for every post type
+func (e *ValidationError) link(field, value string) {
+	if !IsLink(value) {
+		e.Add(field, CodeInvalidLink, "must be an http(s) link, a t.me link, an @username or an email")
+	}
+}
+
+func (e *ValidationError) level(level JobLevel) {
+	if level != JobLevelSkip && !level.Valid() {
+		e.Add("level", CodeInvalid, "must be junior, middle, senior or internship")
+	}
+}
+
+func (e *ValidationError) jobType(t JobType) {
+	if t == "" {
+		e.Add("type", CodeRequired, "is required")
+	} else if !t.Valid() {
+		e.Add("type", CodeInvalid, "must be remote, hybrid or onsite")
+	}
+}
+
+func (e *ValidationError) salary(from, to *int) {
+	if from != nil && *from < 0 {
+		e.Add("salary_from", CodeNegative, "must not be negative")
+	}
+	if to != nil && *to < 0 {
+		e.Add("salary_to", CodeNegative, "must not be negative")
+	}
+	if from != nil && to != nil && *to < *from {
+		e.Add("salary_to", CodeBelowMin, "must not be less than salary_from")
+	}
+}
+
+func (e *ValidationError) language(language string) {
+	if language != "" && language != "en" && language != "ru" {
+		e.Add("language", CodeInvalid, "must be en or ru")
+	}
+}
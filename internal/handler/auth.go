@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+type principalKey struct{}
+
+// Authenticator verifies the caller of the API:
+//
+//	Authorization: tma <initData>  — Telegram Mini App, signed by Telegram
+//	X-API-Key: tj_...              — issued API key for server-to-server calls
+type Authenticator struct {
+	authService *service.AuthService
+}
+
+func NewAuthenticator(authService *service.AuthService) *Authenticator {
+	return &Authenticator{authService: authService}
+}
+
+// RequireAuth rejects requests without valid credentials and puts the
+// verified principal in the request context
+func (a *Authenticator) RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var principal *domain.Principal
+		var err error
+
+		if initData, ok := strings.CutPrefix(r.Header.Get("Authorization"), "tma "); ok {
+			principal, err = a.authService.AuthenticateInitData(initData)
+		} else if key := r.Header.Get("X-API-Key"); key != "" {
+			principal, err = a.authService.AuthenticateAPIKey(r.Context(), key)
+		} else {
+			err = service.ErrUnauthorized
+		}
+
+		if err == service.ErrUnauthorized {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), principalKey{}, principal)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// PrincipalFrom returns the caller verified by RequireAuth
+func PrincipalFrom(ctx context.Context) *domain.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*domain.Principal)
+	return principal
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+// DraftHandler backs the post submission form of the Telegram Mini App
+type DraftHandler struct {
+	cfg          *config.Config
+	draftService *service.DraftService
+}
+
+func NewDraftHandler(cfg *config.Config, draftService *service.DraftService) *DraftHandler {
+	return &DraftHandler{cfg: cfg, draftService: draftService}
+}
+
+// draftResponse is a draft with what still has to be fixed before submitting
+type draftResponse struct {
+	*domain.Draft
+	Errors []domain.FieldError `json:"errors"`
+}
+
+func newDraftResponse(draft *domain.Draft) draftResponse {
+	fields := domain.FieldErrors(draft.Validate())
+	if fields == nil {
+		fields = []domain.FieldError{}
+	}
+	return draftResponse{Draft: draft, Errors: fields}
+}
+
+// Me lets the Mini App check its initData and learn who the user is
+func (h *DraftHandler) Me(w http.ResponseWriter, r *http.Request) {
+	principal := PrincipalFrom(r.Context())
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"telegram_id": principal.TelegramID,
+		"username":    principal.Username,
+		"kind":        principal.Kind,
+		"is_admin":    h.cfg.IsAdmin(principal.TelegramID),
+	})
+}
+
+func (h *DraftHandler) CreateDraft(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PostType domain.PostType     `json:"post_type"`
+		Content  domain.DraftContent `json:"content"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	draft, err := h.draftService.Create(r.Context(), PrincipalFrom(r.Context()).TelegramID, req.PostType, req.Content)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newDraftResponse(draft))
+}
+
+func (h *DraftHandler) ListDrafts(w http.ResponseWriter, r *http.Request) {
+	drafts, err := h.draftService.List(r.Context(), PrincipalFrom(r.Context()).TelegramID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items := make([]draftResponse, 0, len(drafts))
+	for i := range drafts {
+		items = append(items, newDraftResponse(&drafts[i]))
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (h *DraftHandler) GetDraft(w http.ResponseWriter, r *http.Request) {
+	draftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid draft id")
+		return
+	}
+
+	draft, err := h.draftService.Get(r.Context(), PrincipalFrom(r.Context()).TelegramID, draftID)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newDraftResponse(draft))
+}
+
+// UpdateDraft replaces the content of the draft with the one sent
+func (h *DraftHandler) UpdateDraft(w http.ResponseWriter, r *http.Request) {
+	draftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid draft id")
+		return
+	}
+
+	var req struct {
+		Content domain.DraftContent `json:"content"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	draft, err := h.draftService.Update(r.Context(), PrincipalFrom(r.Context()).TelegramID, draftID, req.Content)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newDraftResponse(draft))
+}
+
+func (h *DraftHandler) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	draftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid draft id")
+		return
+	}
+
+	if err := h.draftService.Delete(r.Context(), PrincipalFrom(r.Context()).TelegramID, draftID); err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SubmitDraft sends the draft to moderation, like the preview's Submit button in the bot
+func (h *DraftHandler) SubmitDraft(w http.ResponseWriter, r *http.Request) {
+	draftID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid draft id")
+		return
+	}
+
+	principal := PrincipalFrom(r.Context())
+	post, err := h.draftService.Submit(r.Context(), principal.TelegramID, principal.Username, draftID)
+	if err != nil {
+		writeDraftError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":     post.ID,
+		"status": post.Status,
+	})
+}
+
+func writeDraftError(w http.ResponseWriter, err error) {
+	var validationErr *domain.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		writeValidationError(w, validationErr)
+	case err == service.ErrNotFound:
+		writeError(w, http.StatusNotFound, "draft not found")
+	case err == service.ErrDraftSubmitted:
+		writeError(w, http.StatusConflict, "draft already submitted")
+	case err == service.ErrTooManyDrafts:
+		writeError(w, http.StatusConflict, "too many drafts")
+	case err == service.ErrInvalidPostType:
+		writeError(w, http.StatusBadRequest, "invalid post_type")
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
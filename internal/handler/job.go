@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -20,33 +22,122 @@ func NewJobHandler(jobService *service.JobService) *JobHandler {
 }
 
 func (h *JobHandler) CreateJob(w http.ResponseWriter, r *http.Request) {
-	telegramID, err := strconv.ParseInt(r.Header.Get("X-Telegram-ID"), 10, 64)
-	if err != nil {
-		writeError(w, http.StatusUnauthorized, "invalid telegram id")
+	var req domain.CreateJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	h.createJob(w, r, &req)
+}
 
-	var req domain.CreateJobRequest
+func (h *JobHandler) CreateResume(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateResumeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	h.createResume(w, r, &req)
+}
+
+// CreatePost accepts a vacancy or a resume picked by the "post_type" field
+// of the body; the other fields are those of CreateJob or CreateResume
+func (h *JobHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	var discriminator struct {
+		PostType domain.PostType `json:"post_type"`
+	}
+	if err := json.Unmarshal(body, &discriminator); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	switch discriminator.PostType {
+	case domain.PostTypeVacancy:
+		var req domain.CreateJobRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		h.createJob(w, r, &req)
+	case domain.PostTypeResume:
+		var req domain.CreateResumeRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		h.createResume(w, r, &req)
+	default:
+		writeError(w, http.StatusBadRequest, "invalid post_type")
+	}
+}
+
+func (h *JobHandler) createJob(w http.ResponseWriter, r *http.Request, req *domain.CreateJobRequest) {
+	principal := PrincipalFrom(r.Context())
+	job, err := h.jobService.CreateJob(r.Context(), principal.TelegramID, principal.Username, req)
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		writeValidationError(w, validationErr)
+		return
+	}
+	if err == service.ErrInvalidPromoCode {
+		writeError(w, http.StatusBadRequest, "invalid promo code")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":        job.ID,
+		"post_type": job.PostType,
+		"status":    job.Status,
+	})
+}
 
-	username := r.Header.Get("X-Telegram-Username")
-	job, err := h.jobService.CreateJob(r.Context(), telegramID, username, &req)
+func (h *JobHandler) createResume(w http.ResponseWriter, r *http.Request, req *domain.CreateResumeRequest) {
+	principal := PrincipalFrom(r.Context())
+	resume, err := h.jobService.CreateResume(r.Context(), principal.TelegramID, principal.Username, req)
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		writeValidationError(w, validationErr)
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	writeJSON(w, http.StatusCreated, map[string]interface{}{
-		"id":     job.ID,
-		"status": job.Status,
+		"id":        resume.ID,
+		"post_type": resume.PostType,
+		"status":    resume.Status,
 	})
 }
 
-func (h *JobHandler) GetPendingJobs(w http.ResponseWriter, r *http.Request) {
-	jobs, err := h.jobService.GetPendingJobs(r.Context())
+// ListJobs returns posts in the ?status= given (pending by default) to admins
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	status := domain.JobStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		status = domain.JobStatusPending
+	case domain.JobStatusPending, domain.JobStatusApproved, domain.JobStatusPublished,
+		domain.JobStatusRejected, domain.JobStatusArchived:
+	default:
+		writeError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+
+	principal := PrincipalFrom(r.Context())
+	jobs, err := h.jobService.GetJobsByStatus(r.Context(), status, principal)
+	if err == service.ErrForbidden {
+		writeError(w, http.StatusForbidden, "forbidden")
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -62,13 +153,32 @@ func (h *JobHandler) ApproveJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	adminID, err := strconv.ParseInt(r.Header.Get("X-Telegram-ID"), 10, 64)
-	if err != nil {
-		writeError(w, http.StatusUnauthorized, "invalid telegram id")
+	principal := PrincipalFrom(r.Context())
+
+	// Optional: {"publish_at": "2026-01-01T12:00:00Z"} approves now and publishes later
+	var req struct {
+		PublishAt *time.Time           `json:"publish_at"`
+		Tier      domain.PlacementTier `json:"tier"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	err = h.jobService.ApproveJob(r.Context(), jobID, adminID)
+	switch req.Tier {
+	case "":
+		req.Tier = domain.PlacementStandard
+	case domain.PlacementStandard, domain.PlacementFeatured:
+	default:
+		writeError(w, http.StatusBadRequest, "invalid tier")
+		return
+	}
+
+	if req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		err = h.jobService.ScheduleJob(r.Context(), jobID, principal, req.Tier, *req.PublishAt)
+	} else {
+		err = h.jobService.ApproveJob(r.Context(), jobID, principal, req.Tier)
+	}
 	if err != nil {
 		switch err {
 		case service.ErrForbidden:
@@ -77,16 +187,28 @@ func (h *JobHandler) ApproveJob(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusNotFound, "job not found")
 		case service.ErrInvalidTransition:
 			writeError(w, http.StatusBadRequest, "invalid status transition")
+		case service.ErrPaymentRequired:
+			writeError(w, http.StatusPaymentRequired, "paid payment required")
 		default:
 			writeError(w, http.StatusInternalServerError, err.Error())
 		}
 		return
 	}
 
-	job, _ := h.jobService.GetJobWithCompany(r.Context(), jobID)
+	job, err := h.jobService.GetJobWithCompany(r.Context(), jobID)
+	if err == service.ErrNotFound {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"status":       job.Status,
 		"published_at": job.PublishedAt,
+		"publish_at":   job.PublishAt,
+		"tier":         job.Tier,
 	})
 }
 
@@ -97,18 +219,14 @@ func (h *JobHandler) RejectJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	adminID, err := strconv.ParseInt(r.Header.Get("X-Telegram-ID"), 10, 64)
-	if err != nil {
-		writeError(w, http.StatusUnauthorized, "invalid telegram id")
-		return
-	}
+	principal := PrincipalFrom(r.Context())
 
 	var req struct {
 		Reason string `json:"reason"`
 	}
 	json.NewDecoder(r.Body).Decode(&req)
 
-	err = h.jobService.RejectJob(r.Context(), jobID, adminID, req.Reason)
+	err = h.jobService.RejectJob(r.Context(), jobID, principal, req.Reason)
 	if err != nil {
 		switch err {
 		case service.ErrForbidden:
@@ -124,10 +242,69 @@ func (h *JobHandler) RejectJob(w http.ResponseWriter, r *http.Request) {
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"status": domain.JobStatusRejected,
+		"status":        domain.JobStatusRejected,
+		"status_reason": req.Reason,
 	})
 }
 
+// RetryPublish requeues an approved post whose publishing failed for good
+func (h *JobHandler) RetryPublish(w http.ResponseWriter, r *http.Request) {
+	jobID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid job id")
+		return
+	}
+
+	principal := PrincipalFrom(r.Context())
+
+	err = h.jobService.RetryPublish(r.Context(), jobID, principal)
+	if err != nil {
+		switch err {
+		case service.ErrForbidden:
+			writeError(w, http.StatusForbidden, "forbidden")
+		case service.ErrNotFound:
+			writeError(w, http.StatusNotFound, "job not found")
+		case service.ErrNoFailedPublish:
+			writeError(w, http.StatusConflict, "no failed publish task")
+		default:
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": domain.JobStatusApproved,
+	})
+}
+
+func (h *JobHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	jobID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid job id")
+		return
+	}
+
+	principal := PrincipalFrom(r.Context())
+
+	events, err := h.jobService.GetHistory(r.Context(), jobID, principal)
+	if err != nil {
+		switch err {
+		case service.ErrForbidden:
+			writeError(w, http.StatusForbidden, "forbidden")
+		case service.ErrNotFound:
+			writeError(w, http.StatusNotFound, "job not found")
+		default:
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	if events == nil {
+		events = []domain.PostEvent{}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -137,3 +314,13 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 func writeError(w http.ResponseWriter, status int, message string) {
 	writeJSON(w, status, map[string]string{"error": message})
 }
+
+// writeValidationError reports every invalid field at once, so a form can
+// highlight them
+func writeValidationError(w http.ResponseWriter, err *domain.ValidationError) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"error":   "validation_error",
+		"message": err.Error(),
+		"fields":  err.Fields,
+	})
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+type PaymentHandler struct {
+	paymentService *service.PaymentService
+}
+
+func NewPaymentHandler(paymentService *service.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService}
+}
+
+func (h *PaymentHandler) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	adminID := PrincipalFrom(r.Context()).TelegramID
+
+	var req domain.CreatePaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	payment, err := h.paymentService.RecordPayment(r.Context(), adminID, &req)
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, payment)
+}
+
+func (h *PaymentHandler) MarkPaid(w http.ResponseWriter, r *http.Request) {
+	h.settle(w, r, h.paymentService.MarkPaid)
+}
+
+func (h *PaymentHandler) MarkFailed(w http.ResponseWriter, r *http.Request) {
+	h.settle(w, r, h.paymentService.MarkFailed)
+}
+
+func (h *PaymentHandler) ListPostPayments(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid job id")
+		return
+	}
+
+	adminID := PrincipalFrom(r.Context()).TelegramID
+
+	payments, err := h.paymentService.ListPostPayments(r.Context(), adminID, postID)
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	if payments == nil {
+		payments = []domain.Payment{}
+	}
+	writeJSON(w, http.StatusOK, payments)
+}
+
+func (h *PaymentHandler) settle(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, adminID int64, id uuid.UUID) (*domain.Payment, error)) {
+	paymentID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid payment id")
+		return
+	}
+
+	adminID := PrincipalFrom(r.Context()).TelegramID
+
+	payment, err := fn(r.Context(), adminID, paymentID)
+	if err != nil {
+		writePaymentError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, payment)
+}
+
+func writePaymentError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrForbidden:
+		writeError(w, http.StatusForbidden, "forbidden")
+	case service.ErrNotFound:
+		writeError(w, http.StatusNotFound, "not found")
+	case service.ErrInvalidPayment:
+		writeError(w, http.StatusBadRequest, "invalid payment")
+	case service.ErrInvalidTransition:
+		writeError(w, http.StatusConflict, "payment already settled")
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/service"
+)
+
+// PostHandler is the public read-only API over published posts
+type PostHandler struct {
+	postService *service.PostService
+}
+
+func NewPostHandler(postService *service.PostService) *PostHandler {
+	return &PostHandler{postService: postService}
+}
+
+func (h *PostHandler) ListPosts(w http.ResponseWriter, r *http.Request) {
+	filter, err := parsePostListFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	posts, next, err := h.postService.ListPosts(r.Context(), filter)
+	if err == service.ErrInvalidCursor {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var nextCursor *string
+	if next != nil {
+		token := service.EncodeCursor(next)
+		nextCursor = &token
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"items":       posts,
+		"next_cursor": nextCursor,
+	})
+}
+
+func (h *PostHandler) GetPost(w http.ResponseWriter, r *http.Request) {
+	postID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid post id")
+		return
+	}
+
+	post, err := h.postService.GetPost(r.Context(), postID)
+	if err == service.ErrNotFound {
+		writeError(w, http.StatusNotFound, "post not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, post)
+}
+
+// badParam is returned by parsePostListFilter with the name of the invalid parameter
+type badParam string
+
+func (p badParam) Error() string { return "invalid " + string(p) }
+
+func parsePostListFilter(r *http.Request) (domain.PostListFilter, error) {
+	q := r.URL.Query()
+	var filter domain.PostListFilter
+
+	if v := q.Get("post_type"); v != "" {
+		postType := domain.PostType(v)
+		if postType != domain.PostTypeVacancy && postType != domain.PostTypeResume {
+			return filter, badParam("post_type")
+		}
+		filter.PostType = &postType
+	}
+	if v := q.Get("level"); v != "" {
+		level := domain.JobLevel(v)
+		switch level {
+		case domain.JobLevelJunior, domain.JobLevelMiddle, domain.JobLevelSenior, domain.JobLevelInternship:
+		default:
+			return filter, badParam("level")
+		}
+		filter.Level = &level
+	}
+	if v := q.Get("type"); v != "" {
+		jobType := domain.JobType(v)
+		switch jobType {
+		case domain.JobTypeRemote, domain.JobTypeHybrid, domain.JobTypeOnsite:
+		default:
+			return filter, badParam("type")
+		}
+		filter.Type = &jobType
+	}
+	if v := q.Get("category"); v != "" {
+		category := domain.JobCategory(v)
+		switch category {
+		case domain.JobCategoryWeb2, domain.JobCategoryWeb3, domain.JobCategoryDev:
+		default:
+			return filter, badParam("category")
+		}
+		filter.Category = &category
+	}
+
+	var err error
+	if filter.SalaryMin, err = intParam(q.Get("salary_min"), "salary_min"); err != nil {
+		return filter, err
+	}
+	if filter.SalaryMax, err = intParam(q.Get("salary_max"), "salary_max"); err != nil {
+		return filter, err
+	}
+	if filter.PublishedAfter, err = timeParam(q.Get("published_after"), "published_after"); err != nil {
+		return filter, err
+	}
+	if filter.PublishedBefore, err = timeParam(q.Get("published_before"), "published_before"); err != nil {
+		return filter, err
+	}
+	filter.Language = q.Get("language")
+
+	switch sort := domain.PostSort(q.Get("sort")); sort {
+	case "", domain.SortNewest, domain.SortOldest, domain.SortSalary:
+		filter.Sort = sort
+	default:
+		return filter, badParam("sort")
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return filter, badParam("limit")
+		}
+		filter.Limit = limit
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		cursor, err := service.DecodeCursor(v)
+		if err != nil {
+			return filter, badParam("cursor")
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
+}
+
+func intParam(v, name string) (*int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return nil, badParam(name)
+	}
+	return &n, nil
+}
+
+// timeParam accepts RFC 3339 timestamps or plain dates (UTC midnight)
+func timeParam(v, name string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, v); err != nil {
+			return nil, badParam(name)
+		}
+	}
+	return &t, nil
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func NewRouter(jobHandler *JobHandler) *chi.Mux {
+func NewRouter(authenticator *Authenticator, jobHandler *JobHandler, paymentHandler *PaymentHandler, postHandler *PostHandler, draftHandler *DraftHandler) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middleware.Logger)
@@ -14,12 +16,55 @@ func NewRouter(jobHandler *JobHandler) *chi.Mux {
 
 	r.Route("/api", func(r chi.Router) {
 		r.Route("/jobs", func(r chi.Router) {
+			r.Use(authenticator.RequireAuth)
 			r.Post("/", jobHandler.CreateJob)
-			r.Get("/", jobHandler.GetPendingJobs)
+			r.Get("/", jobHandler.ListJobs)
 			r.Post("/{id}/approve", jobHandler.ApproveJob)
 			r.Post("/{id}/reject", jobHandler.RejectJob)
+			r.Post("/{id}/retry-publish", jobHandler.RetryPublish)
+			r.Get("/{id}/history", jobHandler.GetHistory)
+			r.Get("/{id}/payments", paymentHandler.ListPostPayments)
+		})
+
+		r.Route("/resumes", func(r chi.Router) {
+			r.Use(authenticator.RequireAuth)
+			r.Post("/", jobHandler.CreateResume)
+		})
+
+		// Public read-only board for partner sites; submitting needs auth
+		r.Route("/posts", func(r chi.Router) {
+			r.With(authenticator.RequireAuth).Post("/", jobHandler.CreatePost)
+			r.With(allowAnyOrigin).Get("/", postHandler.ListPosts)
+			r.With(allowAnyOrigin).Get("/{id}", postHandler.GetPost)
+		})
+
+		// Telegram Mini App: the submission form keeps drafts between sessions
+		r.With(authenticator.RequireAuth).Get("/me", draftHandler.Me)
+		r.Route("/drafts", func(r chi.Router) {
+			r.Use(authenticator.RequireAuth)
+			r.Post("/", draftHandler.CreateDraft)
+			r.Get("/", draftHandler.ListDrafts)
+			r.Get("/{id}", draftHandler.GetDraft)
+			r.Put("/{id}", draftHandler.UpdateDraft)
+			r.Delete("/{id}", draftHandler.DeleteDraft)
+			r.Post("/{id}/submit", draftHandler.SubmitDraft)
+		})
+
+		r.Route("/payments", func(r chi.Router) {
+			r.Use(authenticator.RequireAuth)
+			r.Post("/", paymentHandler.RecordPayment)
+			r.Post("/{id}/paid", paymentHandler.MarkPaid)
+			r.Post("/{id}/failed", paymentHandler.MarkFailed)
 		})
 	})
 
 	return r
 }
+
+// allowAnyOrigin lets browsers on other sites call the public read-only endpoints
+func allowAnyOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -2,39 +2,107 @@ package publisher
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"telegram-job/internal/config"
 	"telegram-job/internal/domain"
 )
 
 type ChannelPublisher struct {
 	bot       *tgbotapi.BotAPI
 	channelID int64
+	postLink  func(messageID int) string
 }
 
-func NewChannelPublisher(bot *tgbotapi.BotAPI, channelID int64) *ChannelPublisher {
+func NewChannelPublisher(bot *tgbotapi.BotAPI, cfg *config.Config) *ChannelPublisher {
 	return &ChannelPublisher{
 		bot:       bot,
-		channelID: channelID,
+		channelID: cfg.ChannelID,
+		postLink:  cfg.ChannelPostLink,
 	}
 }
 
 func (p *ChannelPublisher) Publish(ctx context.Context, post *domain.PostWithDetails) (int, error) {
-	var text string
-	if post.PostType == domain.PostTypeResume {
-		text = formatResumePost(post)
-	} else {
-		text = formatJobPost(post)
+	msg := tgbotapi.NewMessage(p.channelID, FormatPost(post))
+	msg.ParseMode = "Markdown"
+	msg.DisableWebPagePreview = true
+
+	sent, err := p.bot.Send(msg)
+	if err != nil {
+		var tgErr *tgbotapi.Error
+		if errors.As(err, &tgErr) && tgErr.RetryAfter > 0 {
+			return 0, &domain.RetryAfterError{RetryAfter: time.Duration(tgErr.RetryAfter) * time.Second, Err: err}
+		}
+		return 0, err
 	}
+	return sent.MessageID, nil
+}
 
-	msg := tgbotapi.NewMessage(p.channelID, text)
+// Edit replaces the text of an already published channel message. Editing it
+// to the text it already has is not an error, so an edit can be repeated.
+func (p *ChannelPublisher) Edit(ctx context.Context, messageID int, post *domain.PostWithDetails) error {
+	edit := tgbotapi.NewEditMessageText(p.channelID, messageID, FormatPost(post))
+	edit.ParseMode = "Markdown"
+	edit.DisableWebPagePreview = true
+
+	_, err := p.bot.Send(edit)
+	var tgErr *tgbotapi.Error
+	if errors.As(err, &tgErr) && strings.Contains(tgErr.Message, "message is not modified") {
+		return nil
+	}
+	return err
+}
+
+// MarkClosed keeps the channel message but marks the post as closed
+func (p *ChannelPublisher) MarkClosed(ctx context.Context, messageID int, post *domain.PostWithDetails) error {
+	text := "🔒 *CLOSED*\n\n" + FormatPost(post)
+	edit := tgbotapi.NewEditMessageText(p.channelID, messageID, text)
+	edit.ParseMode = "Markdown"
+	edit.DisableWebPagePreview = true
+
+	_, err := p.bot.Send(edit)
+	return err
+}
+
+// Pin pins a channel message without notifying subscribers
+func (p *ChannelPublisher) Pin(ctx context.Context, messageID int) error {
+	pin := tgbotapi.PinChatMessageConfig{
+		ChatID:              p.channelID,
+		MessageID:           messageID,
+		DisableNotification: true,
+	}
+	_, err := p.bot.Request(pin)
+	return err
+}
+
+func (p *ChannelPublisher) Unpin(ctx context.Context, messageID int) error {
+	unpin := tgbotapi.UnpinChatMessageConfig{
+		ChatID:    p.channelID,
+		MessageID: messageID,
+	}
+	_, err := p.bot.Request(unpin)
+	return err
+}
+
+// maxDigestPosts keeps a digest well under Telegram's 4096 character limit
+const maxDigestPosts = 40
+
+// PublishDigest posts a summary of the posts published in [from, to) with links to them
+func (p *ChannelPublisher) PublishDigest(ctx context.Context, posts []domain.PostWithDetails, from, to time.Time) (int, error) {
+	msg := tgbotapi.NewMessage(p.channelID, p.formatDigest(posts, from, to))
 	msg.ParseMode = "Markdown"
 	msg.DisableWebPagePreview = true
 
 	sent, err := p.bot.Send(msg)
 	if err != nil {
+		var tgErr *tgbotapi.Error
+		if errors.As(err, &tgErr) && tgErr.RetryAfter > 0 {
+			return 0, &domain.RetryAfterError{RetryAfter: time.Duration(tgErr.RetryAfter) * time.Second, Err: err}
+		}
 		return 0, err
 	}
 	return sent.MessageID, nil
@@ -57,6 +125,59 @@ func escapeMarkdown(s string) string {
 	return replacer.Replace(s)
 }
 
+func (p *ChannelPublisher) formatDigest(posts []domain.PostWithDetails, from, to time.Time) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#digest\n\n📬 *Weekly digest* · %s – %s\n%d new posts\n",
+		from.Format("Jan 2"), to.Add(-time.Second).Format("Jan 2"), len(posts))
+
+	for i, post := range posts {
+		if i == maxDigestPosts {
+			fmt.Fprintf(&sb, "\n…and %d more", len(posts)-maxDigestPosts)
+			break
+		}
+
+		sb.WriteString("\n" + FormatPostLine(&post, p.postLink(*post.ChannelMessageID), "from $%d"))
+	}
+	return sb.String()
+}
+
+// FormatPostLine is a one-line summary of a published post linking to its
+// channel message. salaryFrom formats the lower salary bound, e.g. "From $%d".
+func FormatPostLine(post *domain.PostWithDetails, link, salaryFrom string) string {
+	emoji := "🏢"
+	if post.PostType == domain.PostTypeResume {
+		emoji = "👤"
+	}
+
+	// Brackets would end the link text early
+	title := strings.NewReplacer("[", "(", "]", ")").Replace(post.Title)
+	return fmt.Sprintf("%s [%s](%s) — %s", emoji, title, link, escapeMarkdown(PostDetails(post, salaryFrom)))
+}
+
+// PostDetails is the company, level, format and salary of a post as plain text
+func PostDetails(post *domain.PostWithDetails, salaryFrom string) string {
+	var details []string
+	if post.PostType != domain.PostTypeResume && post.CompanyName != "" {
+		details = append(details, post.CompanyName)
+	}
+	if post.Level != "" {
+		details = append(details, string(post.Level))
+	}
+	details = append(details, string(post.Type))
+	if post.SalaryFrom != nil {
+		details = append(details, fmt.Sprintf(salaryFrom, *post.SalaryFrom))
+	}
+	return strings.Join(details, " · ")
+}
+
+// FormatPost renders a post the way it appears in the channel
+func FormatPost(post *domain.PostWithDetails) string {
+	if post.PostType == domain.PostTypeResume {
+		return formatResumePost(post)
+	}
+	return formatJobPost(post)
+}
+
 func formatJobPost(post *domain.PostWithDetails) string {
 	salary := "Not specified"
 	if post.SalaryFrom != nil && post.SalaryTo != nil {
@@ -0,0 +1,110 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/testutil"
+)
+
+const testChannelID = -1001234567890
+
+func newTestPublisher(t *testing.T, cfg *config.Config) (*ChannelPublisher, *testutil.FakeBotAPI) {
+	t.Helper()
+	if cfg == nil {
+		cfg = &config.Config{ChannelID: testChannelID}
+	}
+	fake := testutil.NewFakeBotAPI(t)
+	return NewChannelPublisher(fake.API(t), cfg), fake
+}
+
+func testPost() *domain.PostWithDetails {
+	salary := 3000
+	return &domain.PostWithDetails{
+		Post: domain.Post{
+			ID:         uuid.New(),
+			PostType:   domain.PostTypeVacancy,
+			Title:      "Go developer",
+			Level:      domain.JobLevelSenior,
+			Type:       domain.JobTypeRemote,
+			Category:   domain.JobCategoryDev,
+			SalaryFrom: &salary,
+			ApplyLink:  "https://example.com/apply",
+		},
+		CompanyName: "Acme",
+	}
+}
+
+func TestPublishReturnsMessageID(t *testing.T) {
+	p, fake := newTestPublisher(t, nil)
+
+	messageID, err := p.Publish(context.Background(), testPost())
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	calls := fake.Calls("sendMessage")
+	if len(calls) != 1 {
+		t.Fatalf("sendMessage called %d times, want 1", len(calls))
+	}
+	if got := calls[0].Params.Get("chat_id"); got != "-1001234567890" {
+		t.Errorf("chat_id = %s, want the channel", got)
+	}
+	if !strings.Contains(calls[0].Params.Get("text"), "Go developer") {
+		t.Errorf("text doesn't contain the title: %q", calls[0].Params.Get("text"))
+	}
+	if messageID != 101 {
+		t.Errorf("message ID = %d, want 101", messageID)
+	}
+}
+
+func TestPublishRetryAfter(t *testing.T) {
+	p, fake := newTestPublisher(t, nil)
+	fake.Handle("sendMessage", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 429, Description: "Too Many Requests: retry after 30", RetryAfter: 30}
+	})
+
+	_, err := p.Publish(context.Background(), testPost())
+
+	var retryErr *domain.RetryAfterError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Publish error = %v, want a RetryAfterError", err)
+	}
+	if retryErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %s, want 30s", retryErr.RetryAfter)
+	}
+}
+
+func TestPublishOtherErrorIsNotRetryAfter(t *testing.T) {
+	p, fake := newTestPublisher(t, nil)
+	fake.Handle("sendMessage", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 400, Description: "Bad Request: chat not found"}
+	})
+
+	_, err := p.Publish(context.Background(), testPost())
+	if err == nil {
+		t.Fatal("Publish succeeded, want an error")
+	}
+	var retryErr *domain.RetryAfterError
+	if errors.As(err, &retryErr) {
+		t.Errorf("Publish error = %v, want a plain error", err)
+	}
+}
+
+func TestEditUnchangedMessageSucceeds(t *testing.T) {
+	p, fake := newTestPublisher(t, nil)
+	fake.Handle("editMessageText", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 400, Description: "Bad Request: message is not modified"}
+	})
+
+	if err := p.Edit(context.Background(), 42, testPost()); err != nil {
+		t.Errorf("Edit = %v, want nil for an unchanged message", err)
+	}
+}
@@ -0,0 +1,33 @@
+package publisher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+)
+
+func TestPublishDigestUsesChannelPostLink(t *testing.T) {
+	p, fake := newTestPublisher(t, &config.Config{ChannelID: testChannelID, ChannelUsername: "jobs"})
+
+	post := *testPost()
+	messageID := 7
+	post.ChannelMessageID = &messageID
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	if _, err := p.PublishDigest(context.Background(), []domain.PostWithDetails{post}, from, from.AddDate(0, 0, 7)); err != nil {
+		t.Fatalf("PublishDigest: %v", err)
+	}
+
+	calls := fake.Calls("sendMessage")
+	if len(calls) != 1 {
+		t.Fatalf("sendMessage called %d times, want 1", len(calls))
+	}
+	text := calls[0].Params.Get("text")
+	if !strings.Contains(text, "[Go developer](https://t.me/jobs/7)") {
+		t.Errorf("digest doesn't link the post through the public channel: %q", text)
+	}
+}
@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"telegram-job/internal/testutil"
+)
+
+func TestPinAndUnpin(t *testing.T) {
+	p, fake := newTestPublisher(t, nil)
+	ctx := context.Background()
+
+	if err := p.Pin(ctx, 42); err != nil {
+		t.Fatalf("Pin: %v", err)
+	}
+	if err := p.Unpin(ctx, 42); err != nil {
+		t.Fatalf("Unpin: %v", err)
+	}
+
+	pins := fake.Calls("pinChatMessage")
+	if len(pins) != 1 {
+		t.Fatalf("pinChatMessage called %d times, want 1", len(pins))
+	}
+	if got := pins[0].Params.Get("message_id"); got != "42" {
+		t.Errorf("pinned message_id = %s, want 42", got)
+	}
+	if got := pins[0].Params.Get("disable_notification"); got != "true" {
+		t.Errorf("disable_notification = %q, want true", got)
+	}
+
+	unpins := fake.Calls("unpinChatMessage")
+	if len(unpins) != 1 {
+		t.Fatalf("unpinChatMessage called %d times, want 1", len(unpins))
+	}
+	if got := unpins[0].Params.Get("message_id"); got != "42" {
+		t.Errorf("unpinned message_id = %s, want 42", got)
+	}
+	if got := unpins[0].Params.Get("chat_id"); got != "-1001234567890" {
+		t.Errorf("unpin chat_id = %s, want the channel", got)
+	}
+}
+
+func TestPinError(t *testing.T) {
+	p, fake := newTestPublisher(t, nil)
+	fake.Handle("pinChatMessage", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 400, Description: "Bad Request: not enough rights to pin a message"}
+	})
+
+	if err := p.Pin(context.Background(), 42); err == nil {
+		t.Fatal("Pin succeeded, want an error")
+	}
+}
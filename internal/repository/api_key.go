@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type APIKeyRepository struct {
+	db *DB
+}
+
+func NewAPIKeyRepository(db *DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, name, telegram_id, created_by, last_used_at, revoked_at, created_at`
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey, keyHash string) error {
+	query := `
+		INSERT INTO api_keys (id, name, key_hash, telegram_id, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+	key.ID = uuid.New()
+	return r.db.Pool.QueryRow(ctx, query, key.ID, key.Name, keyHash, key.TelegramID, key.CreatedBy).Scan(&key.CreatedAt)
+}
+
+// GetActiveByHash returns the unrevoked key with the given hash, or nil if there is none
+func (r *APIKeyRepository) GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+	key, err := scanAPIKey(r.db.Pool.QueryRow(ctx, query, keyHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return key, err
+}
+
+// List returns all keys, newest first
+func (r *APIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+// Revoke disables a key. It returns false if the key doesn't exist or is already revoked.
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *APIKeyRepository) SetLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.Pool.Exec(ctx, `UPDATE api_keys SET last_used_at = $1 WHERE id = $2`, at, id)
+	return err
+}
+
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.TelegramID,
+		&key.CreatedBy,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
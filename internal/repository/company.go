@@ -74,3 +74,9 @@ func (r *CompanyRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 	}
 	return &company, nil
 }
+
+func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company) error {
+	query := `UPDATE companies SET name = $1, contact = $2 WHERE id = $3`
+	_, err := r.db.Pool.Exec(ctx, query, company.Name, company.Contact, company.ID)
+	return err
+}
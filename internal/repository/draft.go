@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type DraftRepository struct {
+	db *DB
+}
+
+func NewDraftRepository(db *DB) *DraftRepository {
+	return &DraftRepository{db: db}
+}
+
+const draftColumns = `id, telegram_id, post_type, content, submitted_at, post_id, created_at, updated_at`
+
+func (r *DraftRepository) Create(ctx context.Context, draft *domain.Draft) error {
+	query := `
+		INSERT INTO drafts (id, telegram_id, post_type, content)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at
+	`
+	content, err := json.Marshal(draft.Content)
+	if err != nil {
+		return err
+	}
+	draft.ID = uuid.New()
+	return r.db.Pool.QueryRow(ctx, query,
+		draft.ID,
+		draft.TelegramID,
+		draft.PostType,
+		content,
+	).Scan(&draft.CreatedAt, &draft.UpdatedAt)
+}
+
+func (r *DraftRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Draft, error) {
+	query := `SELECT ` + draftColumns + ` FROM drafts WHERE id = $1`
+	return scanDraft(r.db.Pool.QueryRow(ctx, query, id))
+}
+
+// ListOpen returns the drafts of a user that weren't submitted, recently edited first
+func (r *DraftRepository) ListOpen(ctx context.Context, telegramID int64) ([]domain.Draft, error) {
+	query := `SELECT ` + draftColumns + ` FROM drafts WHERE telegram_id = $1 AND submitted_at IS NULL ORDER BY updated_at DESC`
+	rows, err := r.db.Pool.Query(ctx, query, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drafts []domain.Draft
+	for rows.Next() {
+		draft, err := scanDraft(rows)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, *draft)
+	}
+	return drafts, rows.Err()
+}
+
+func (r *DraftRepository) CountOpen(ctx context.Context, telegramID int64) (int, error) {
+	var count int
+	err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM drafts WHERE telegram_id = $1 AND submitted_at IS NULL`, telegramID).Scan(&count)
+	return count, err
+}
+
+// UpdateContent replaces the content of an open draft. It returns false if
+// the draft was submitted in the meantime.
+func (r *DraftRepository) UpdateContent(ctx context.Context, draft *domain.Draft) (bool, error) {
+	query := `
+		UPDATE drafts SET content = $1, updated_at = now()
+		WHERE id = $2 AND submitted_at IS NULL
+		RETURNING updated_at
+	`
+	content, err := json.Marshal(draft.Content)
+	if err != nil {
+		return false, err
+	}
+	err = r.db.Pool.QueryRow(ctx, query, content, draft.ID).Scan(&draft.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *DraftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Pool.Exec(ctx, `DELETE FROM drafts WHERE id = $1`, id)
+	return err
+}
+
+// ClaimSubmit marks an open draft as being submitted. It returns false if it
+// already was, so two concurrent submits create only one post.
+func (r *DraftRepository) ClaimSubmit(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `UPDATE drafts SET submitted_at = now() WHERE id = $1 AND submitted_at IS NULL`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReleaseSubmit reopens a draft whose submission failed
+func (r *DraftRepository) ReleaseSubmit(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Pool.Exec(ctx, `UPDATE drafts SET submitted_at = NULL WHERE id = $1 AND post_id IS NULL`, id)
+	return err
+}
+
+func (r *DraftRepository) SetPost(ctx context.Context, id uuid.UUID, postID uuid.UUID) error {
+	_, err := r.db.Pool.Exec(ctx, `UPDATE drafts SET post_id = $1 WHERE id = $2`, postID, id)
+	return err
+}
+
+func scanDraft(row pgx.Row) (*domain.Draft, error) {
+	var draft domain.Draft
+	var content []byte
+	err := row.Scan(
+		&draft.ID,
+		&draft.TelegramID,
+		&draft.PostType,
+		&content,
+		&draft.SubmittedAt,
+		&draft.PostID,
+		&draft.CreatedAt,
+		&draft.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &draft.Content); err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
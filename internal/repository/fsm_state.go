@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// FSMStateRecord is the stored form of a bot conversation state.
+// Draft and Search hold JSON owned by the bot package; Search may be nil.
+type FSMStateRecord struct {
+	TelegramID   int64
+	State        int
+	Language     string
+	Draft        []byte
+	Editing      bool
+	RejectPostID string
+	Search       []byte
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	RemindedAt   *time.Time
+}
+
+type FSMStateRepository struct {
+	db *DB
+}
+
+func NewFSMStateRepository(db *DB) *FSMStateRepository {
+	return &FSMStateRepository{db: db}
+}
+
+const fsmStateColumns = `telegram_id, state, language, draft, editing, reject_post_id, search, created_at, updated_at, reminded_at`
+
+// fsmHasDraft matches rows with a conversation in progress or a non-empty
+// draft. An empty draft is stored as {"post_type": ""}.
+const fsmHasDraft = `(state <> 0 OR draft - 'post_type' <> '{}' OR COALESCE(draft->>'post_type', '') <> '')`
+
+// Get returns nil when there is no stored state for the user
+func (r *FSMStateRepository) Get(ctx context.Context, telegramID int64) (*FSMStateRecord, error) {
+	query := `SELECT ` + fsmStateColumns + ` FROM bot_fsm_states WHERE telegram_id = $1`
+	record, err := scanFSMState(r.db.Pool.QueryRow(ctx, query, telegramID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Update locks the user's row (creating an empty one if there is none),
+// lets fn change it and saves the result in the same transaction, so bot
+// replicas handling the same user never overwrite each other's changes.
+// Nothing is saved if fn returns an error.
+func (r *FSMStateRepository) Update(ctx context.Context, telegramID int64, fn func(record *FSMStateRecord) error) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `INSERT INTO bot_fsm_states (telegram_id) VALUES ($1) ON CONFLICT (telegram_id) DO NOTHING`, telegramID); err != nil {
+		return err
+	}
+
+	query := `SELECT ` + fsmStateColumns + ` FROM bot_fsm_states WHERE telegram_id = $1 FOR UPDATE`
+	record, err := scanFSMState(tx.QueryRow(ctx, query, telegramID))
+	if err != nil {
+		return err
+	}
+	if err := fn(record); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE bot_fsm_states
+		SET state = $2, language = $3, draft = $4, editing = $5, reject_post_id = $6,
+			search = $7, created_at = $8, updated_at = $9, reminded_at = $10
+		WHERE telegram_id = $1
+	`
+	_, err = tx.Exec(ctx, query,
+		telegramID,
+		record.State,
+		record.Language,
+		record.Draft,
+		record.Editing,
+		record.RejectPostID,
+		record.Search,
+		record.CreatedAt,
+		record.UpdatedAt,
+		record.RemindedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *FSMStateRepository) Delete(ctx context.Context, telegramID int64) error {
+	query := `DELETE FROM bot_fsm_states WHERE telegram_id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, telegramID)
+	return err
+}
+
+// ListUpdatedBefore returns the states holding a draft that were last
+// changed before the given time
+func (r *FSMStateRepository) ListUpdatedBefore(ctx context.Context, before time.Time) ([]FSMStateRecord, error) {
+	query := `
+		SELECT ` + fsmStateColumns + `
+		FROM bot_fsm_states
+		WHERE updated_at < $1 AND ` + fsmHasDraft + `
+		ORDER BY updated_at
+	`
+	rows, err := r.db.Pool.Query(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []FSMStateRecord
+	for rows.Next() {
+		record, err := scanFSMState(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	return records, rows.Err()
+}
+
+// ExpireDraft clears the draft of a user idle since before idleSince. The
+// interface language and admin state stay. It returns false if the user was
+// active in the meantime or had no draft.
+func (r *FSMStateRepository) ExpireDraft(ctx context.Context, telegramID int64, idleSince time.Time) (bool, error) {
+	query := `
+		UPDATE bot_fsm_states
+		SET state = 0, draft = '{}', editing = FALSE, reminded_at = NULL
+		WHERE telegram_id = $1 AND updated_at < $2 AND ` + fsmHasDraft + `
+	`
+	tag, err := r.db.Pool.Exec(ctx, query, telegramID, idleSince)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// MarkReminded sets reminded_at only if it was not set yet and the user has
+// been idle since before idleSince, so concurrent bot replicas send at most
+// one reminder per draft and none to a user who came back in the meantime
+func (r *FSMStateRepository) MarkReminded(ctx context.Context, telegramID int64, idleSince time.Time, at time.Time) (bool, error) {
+	query := `
+		UPDATE bot_fsm_states SET reminded_at = $1
+		WHERE telegram_id = $2 AND reminded_at IS NULL AND updated_at < $3
+	`
+	tag, err := r.db.Pool.Exec(ctx, query, at, telegramID, idleSince)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func scanFSMState(row pgx.Row) (*FSMStateRecord, error) {
+	var record FSMStateRecord
+	err := row.Scan(
+		&record.TelegramID,
+		&record.State,
+		&record.Language,
+		&record.Draft,
+		&record.Editing,
+		&record.RejectPostID,
+		&record.Search,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+		&record.RemindedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
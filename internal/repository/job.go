@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"telegram-job/internal/domain"
 )
 
@@ -47,118 +50,421 @@ func (r *JobRepository) Create(ctx context.Context, post *domain.Post) error {
 }
 
 func (r *JobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Post, error) {
-	query := `
-		SELECT id, post_type, user_id, company_id, title, level, type, category, salary_from, salary_to, description, apply_link, status, language, channel_message_id, published_at, created_at, experience_years, employment, about, resume_link, contact
-		FROM posts
-		WHERE id = $1
-	`
+	query := `SELECT ` + postColumns + ` FROM posts p WHERE p.id = $1`
 	var post domain.Post
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
-		&post.ID,
-		&post.PostType,
-		&post.UserID,
-		&post.CompanyID,
-		&post.Title,
-		&post.Level,
-		&post.Type,
-		&post.Category,
-		&post.SalaryFrom,
-		&post.SalaryTo,
-		&post.Description,
-		&post.ApplyLink,
-		&post.Status,
-		&post.Language,
-		&post.ChannelMessageID,
-		&post.PublishedAt,
-		&post.CreatedAt,
-		&post.ExperienceYears,
-		&post.Employment,
-		&post.About,
-		&post.ResumeLink,
-		&post.Contact,
-	)
-	if err != nil {
+	if err := scanPost(r.db.Pool.QueryRow(ctx, query, id), &post); err != nil {
 		return nil, err
 	}
 	return &post, nil
 }
 
 func (r *JobRepository) GetByStatus(ctx context.Context, status domain.JobStatus) ([]domain.PostWithDetails, error) {
-	query := `
-		SELECT
-			p.id, p.post_type, p.user_id, p.company_id, p.title, p.level, p.type, p.category,
-			p.salary_from, p.salary_to, p.description, p.apply_link, p.status, p.language,
-			p.published_at, p.created_at, p.experience_years, p.employment, p.about, p.resume_link, p.contact,
-			COALESCE(c.name, '') as company_name,
-			COALESCE(c.contact, '') as company_contact,
-			COALESCE(u.telegram_id, u2.telegram_id) as author_telegram_id
-		FROM posts p
-		LEFT JOIN companies c ON p.company_id = c.id
-		LEFT JOIN users u ON c.user_id = u.id
-		LEFT JOIN users u2 ON p.user_id = u2.id
+	query := `SELECT ` + postWithDetailsColumns + postWithDetailsJoins + `
 		WHERE p.status = $1
 		ORDER BY p.created_at DESC
 	`
-	rows, err := r.db.Pool.Query(ctx, query, status)
-	if err != nil {
+	return r.listWithDetails(ctx, query, status)
+}
+
+func (r *JobRepository) GetWithCompany(ctx context.Context, id uuid.UUID) (*domain.PostWithDetails, error) {
+	query := `SELECT ` + postWithDetailsColumns + postWithDetailsJoins + `
+		WHERE p.id = $1
+	`
+	var post domain.PostWithDetails
+	if err := scanPostWithDetails(r.db.Pool.QueryRow(ctx, query, id), &post); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return &post, nil
+}
 
-	var posts []domain.PostWithDetails
-	for rows.Next() {
-		var post domain.PostWithDetails
-		err := rows.Scan(
-			&post.ID,
-			&post.PostType,
-			&post.UserID,
-			&post.CompanyID,
-			&post.Title,
-			&post.Level,
-			&post.Type,
-			&post.Category,
-			&post.SalaryFrom,
-			&post.SalaryTo,
-			&post.Description,
-			&post.ApplyLink,
-			&post.Status,
-			&post.Language,
-			&post.PublishedAt,
-			&post.CreatedAt,
-			&post.ExperienceYears,
-			&post.Employment,
-			&post.About,
-			&post.ResumeLink,
-			&post.Contact,
-			&post.CompanyName,
-			&post.CompanyContact,
-			&post.AuthorTelegramID,
-		)
-		if err != nil {
-			return nil, err
+const updatePostContentQuery = `
+	UPDATE posts
+	SET title = $1, level = $2, type = $3, category = $4, salary_from = $5, salary_to = $6,
+		description = $7, apply_link = $8, language = $9, experience_years = $10, employment = $11,
+		about = $12, resume_link = $13, contact = $14
+	WHERE id = $15
+`
+
+// UpdateContent saves the editable fields of a post (status and publication data are left untouched)
+func (r *JobRepository) UpdateContent(ctx context.Context, post *domain.Post) error {
+	_, err := r.db.Pool.Exec(ctx, updatePostContentQuery, postContentArgs(post)...)
+	return err
+}
+
+func postContentArgs(post *domain.Post) []interface{} {
+	return []interface{}{
+		post.Title,
+		post.Level,
+		post.Type,
+		post.Category,
+		post.SalaryFrom,
+		post.SalaryTo,
+		post.Description,
+		post.ApplyLink,
+		post.Language,
+		post.ExperienceYears,
+		post.Employment,
+		post.About,
+		post.ResumeLink,
+		post.Contact,
+		post.ID,
+	}
+}
+
+// Approve moves a post to approved and queues it for publishing at publishAt
+// (now if nil) in one transaction. In the same transaction a standard post
+// uses a credit of the author's prepaid package, unless it was paid otherwise;
+// if creditRequired and no credit is left, nothing is saved and
+// domain.ErrNoPackageCredit is returned.
+func (r *JobRepository) Approve(ctx context.Context, id uuid.UUID, change domain.StatusChange, tier domain.PlacementTier, publishAt *time.Time, actorTelegramID *int64, creditRequired bool) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE posts SET status = $1, publish_at = $2, tier = $3 WHERE id = $4 AND status = $5`
+	if err := changeStatusTx(ctx, tx, id, change, actorTelegramID, "", query, change.To, publishAt, tier, id, change.From); err != nil {
+		return err
+	}
+
+	used, err := usePackageCredit(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if creditRequired && !used {
+		return domain.ErrNoPackageCredit
+	}
+
+	at := time.Now().UTC()
+	if publishAt != nil {
+		at = *publishAt
+	}
+	if err := insertPublishTask(ctx, tx, id, actorTelegramID, at); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetPublished marks a post published and, in the same transaction, queues
+// alerts for the subscribers whose filters match it
+func (r *JobRepository) SetPublished(ctx context.Context, id uuid.UUID, change domain.StatusChange, channelMessageID int, actorTelegramID *int64) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE posts SET status = $1, published_at = $2, channel_message_id = $3 WHERE id = $4 AND status = $5`
+	if err := changeStatusTx(ctx, tx, id, change, actorTelegramID, "", query, change.To, time.Now().UTC(), channelMessageID, id, change.From); err != nil {
+		return err
+	}
+
+	if err := insertJobAlerts(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetPinned records when a featured post was pinned and until when
+func (r *JobRepository) SetPinned(ctx context.Context, id uuid.UUID, pinnedAt time.Time, pinUntil time.Time) error {
+	query := `UPDATE posts SET pinned_at = $1, pin_until = $2, unpinned_at = NULL WHERE id = $3`
+	_, err := r.db.Pool.Exec(ctx, query, pinnedAt, pinUntil, id)
+	return err
+}
+
+// GetPinExpired returns pinned posts whose pin window ended before now
+func (r *JobRepository) GetPinExpired(ctx context.Context, now time.Time) ([]domain.Post, error) {
+	query := `SELECT ` + postColumns + `
+		FROM posts p
+		WHERE p.pinned_at IS NOT NULL AND p.unpinned_at IS NULL AND p.pin_until <= $1
+		ORDER BY p.pin_until
+	`
+	return r.list(ctx, query, now)
+}
+
+// SetUnpinned records the end of the pin. It returns false if the post was
+// already unpinned (e.g. by another bot replica).
+func (r *JobRepository) SetUnpinned(ctx context.Context, id uuid.UUID, unpinnedAt time.Time) (bool, error) {
+	query := `UPDATE posts SET unpinned_at = $1 WHERE id = $2 AND pinned_at IS NOT NULL AND unpinned_at IS NULL`
+	tag, err := r.db.Pool.Exec(ctx, query, unpinnedAt, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// CountPublishedSince returns how many posts went to the channel after since
+func (r *JobRepository) CountPublishedSince(ctx context.Context, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM posts WHERE published_at > $1`
+	var count int
+	err := r.db.Pool.QueryRow(ctx, query, since).Scan(&count)
+	return count, err
+}
+
+// Search returns a page of published posts matching the filter and the total
+// number of matches. Posts are ordered by relevance when there is a query,
+// newest first otherwise.
+func (r *JobRepository) Search(ctx context.Context, filter domain.SearchFilter, limit, offset int) ([]domain.PostWithDetails, int, error) {
+	where := []string{`p.status = 'published'`, `p.channel_message_id IS NOT NULL`}
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	order := `p.published_at DESC`
+	if filter.Query != "" {
+		q := arg(filter.Query)
+		where = append(where, `p.search_vector @@ websearch_to_tsquery('simple', `+q+`)`)
+		order = `ts_rank(p.search_vector, websearch_to_tsquery('simple', ` + q + `)) DESC, p.published_at DESC`
+	}
+	if filter.PostType != nil {
+		where = append(where, `p.post_type = `+arg(*filter.PostType))
+	}
+	if filter.Type != nil {
+		where = append(where, `p.type = `+arg(*filter.Type))
+	}
+	if filter.Level != nil {
+		where = append(where, `p.level = `+arg(*filter.Level))
+	}
+	if filter.Category != nil {
+		where = append(where, `p.category = `+arg(*filter.Category))
+	}
+	if filter.MinSalary != nil {
+		where = append(where, `COALESCE(p.salary_to, p.salary_from) >= `+arg(*filter.MinSalary))
+	}
+	conditions := ` WHERE ` + strings.Join(where, ` AND `)
+
+	var total int
+	if err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM posts p`+conditions, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	query := `SELECT ` + postWithDetailsColumns + postWithDetailsJoins + conditions +
+		` ORDER BY ` + order + ` LIMIT ` + arg(limit) + ` OFFSET ` + arg(offset)
+	posts, err := r.listWithDetails(ctx, query, args...)
+	return posts, total, err
+}
+
+// salarySortKey orders posts by their best salary; posts without one come last
+const salarySortKey = `COALESCE(p.salary_to, p.salary_from, 0)`
+
+// ListPublished returns up to filter.Limit published posts after filter.After
+// using keyset pagination, so pages stay stable while new posts are published
+func (r *JobRepository) ListPublished(ctx context.Context, filter domain.PostListFilter) ([]domain.PostWithDetails, error) {
+	where := []string{`p.status = 'published'`, `p.channel_message_id IS NOT NULL`}
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.PostType != nil {
+		where = append(where, `p.post_type = `+arg(*filter.PostType))
+	}
+	if filter.Level != nil {
+		where = append(where, `p.level = `+arg(*filter.Level))
+	}
+	if filter.Type != nil {
+		where = append(where, `p.type = `+arg(*filter.Type))
+	}
+	if filter.Category != nil {
+		where = append(where, `p.category = `+arg(*filter.Category))
+	}
+	if filter.SalaryMin != nil {
+		where = append(where, `COALESCE(p.salary_to, p.salary_from) >= `+arg(*filter.SalaryMin))
+	}
+	if filter.SalaryMax != nil {
+		where = append(where, `COALESCE(p.salary_from, p.salary_to) <= `+arg(*filter.SalaryMax))
+	}
+	if filter.Language != "" {
+		where = append(where, `p.language = `+arg(filter.Language))
+	}
+	if filter.PublishedAfter != nil {
+		where = append(where, `p.published_at >= `+arg(*filter.PublishedAfter))
+	}
+	if filter.PublishedBefore != nil {
+		where = append(where, `p.published_at < `+arg(*filter.PublishedBefore))
+	}
+
+	var order string
+	switch filter.Sort {
+	case domain.SortOldest:
+		order = `p.published_at, p.id`
+		if c := filter.After; c != nil {
+			where = append(where, `(p.published_at, p.id) > (`+arg(c.PublishedAt)+`, `+arg(c.ID)+`)`)
+		}
+	case domain.SortSalary:
+		order = salarySortKey + ` DESC, p.id DESC`
+		if c := filter.After; c != nil {
+			where = append(where, `(`+salarySortKey+`, p.id) < (`+arg(c.Salary)+`, `+arg(c.ID)+`)`)
+		}
+	default:
+		order = `p.published_at DESC, p.id DESC`
+		if c := filter.After; c != nil {
+			where = append(where, `(p.published_at, p.id) < (`+arg(c.PublishedAt)+`, `+arg(c.ID)+`)`)
 		}
-		posts = append(posts, post)
 	}
-	return posts, nil
+
+	query := `SELECT ` + postWithDetailsColumns + postWithDetailsJoins +
+		` WHERE ` + strings.Join(where, ` AND `) +
+		` ORDER BY ` + order + ` LIMIT ` + arg(filter.Limit)
+	return r.listWithDetails(ctx, query, args...)
 }
 
-func (r *JobRepository) GetWithCompany(ctx context.Context, id uuid.UUID) (*domain.PostWithDetails, error) {
+// ListPublishedBetween returns posts published in [from, to) that are still in the channel, oldest first
+func (r *JobRepository) ListPublishedBetween(ctx context.Context, from, to time.Time) ([]domain.PostWithDetails, error) {
+	query := `SELECT ` + postWithDetailsColumns + postWithDetailsJoins + `
+		WHERE p.status = 'published' AND p.channel_message_id IS NOT NULL
+			AND p.published_at >= $1 AND p.published_at < $2
+		ORDER BY p.published_at
+	`
+	return r.listWithDetails(ctx, query, from, to)
+}
+
+// ClaimChannelDigest records that the channel digest of the period starting
+// at periodStart is being posted. It returns false if it already was.
+func (r *JobRepository) ClaimChannelDigest(ctx context.Context, periodStart time.Time) (bool, error) {
+	query := `INSERT INTO channel_digests (period_start) VALUES ($1) ON CONFLICT DO NOTHING`
+	tag, err := r.db.Pool.Exec(ctx, query, periodStart)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReleaseChannelDigest undoes ClaimChannelDigest after the digest failed to post
+func (r *JobRepository) ReleaseChannelDigest(ctx context.Context, periodStart time.Time) error {
+	_, err := r.db.Pool.Exec(ctx, `DELETE FROM channel_digests WHERE period_start = $1 AND channel_message_id IS NULL`, periodStart)
+	return err
+}
+
+func (r *JobRepository) SetChannelDigestMessage(ctx context.Context, periodStart time.Time, channelMessageID int) error {
+	_, err := r.db.Pool.Exec(ctx, `UPDATE channel_digests SET channel_message_id = $1 WHERE period_start = $2`, channelMessageID, periodStart)
+	return err
+}
+
+// Reject and Archive store the reason on the post as well as in the event
+func (r *JobRepository) Reject(ctx context.Context, id uuid.UUID, change domain.StatusChange, reason string, actorTelegramID *int64) error {
+	query := `UPDATE posts SET status = $1, status_reason = $2 WHERE id = $3 AND status = $4`
+	return r.changeStatus(ctx, id, change, actorTelegramID, reason, query, change.To, reason, id, change.From)
+}
+
+func (r *JobRepository) Archive(ctx context.Context, id uuid.UUID, change domain.StatusChange, reason string, actorTelegramID *int64) error {
+	query := `UPDATE posts SET status = $1, status_reason = $2 WHERE id = $3 AND status = $4`
+	return r.changeStatus(ctx, id, change, actorTelegramID, reason, query, change.To, reason, id, change.From)
+}
+
+// changeStatus runs a conditional status update and records a post_events row
+// in the same transaction
+func (r *JobRepository) changeStatus(ctx context.Context, id uuid.UUID, change domain.StatusChange, actorTelegramID *int64, reason string, query string, args ...interface{}) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := changeStatusTx(ctx, tx, id, change, actorTelegramID, reason, query, args...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// changeStatusTx is changeStatus inside an existing transaction. If the post
+// is no longer in change.From (another admin or worker got there first)
+// nothing is written and domain.ErrInvalidTransition is returned.
+// actorTelegramID is nil for system actions.
+func changeStatusTx(ctx context.Context, tx pgx.Tx, id uuid.UUID, change domain.StatusChange, actorTelegramID *int64, reason string, query string, args ...interface{}) error {
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() != 1 {
+		return domain.ErrInvalidTransition
+	}
+
+	event := &domain.PostEvent{
+		PostID:          id,
+		ActorTelegramID: actorTelegramID,
+		FromStatus:      change.From,
+		ToStatus:        change.To,
+		Reason:          reason,
+	}
+	return insertPostEvent(ctx, tx, event)
+}
+
+func (r *JobRepository) GetExpiredJobs(ctx context.Context, days int) ([]domain.Post, error) {
+	query := `SELECT ` + postColumns + `
+		FROM posts p
+		WHERE p.status = 'published' AND p.published_at < NOW() - INTERVAL '1 day' * $1
+	`
+	return r.list(ctx, query, days)
+}
+
+func (r *JobRepository) GetByUserTelegramID(ctx context.Context, telegramID int64) ([]domain.Post, error) {
+	query := `SELECT ` + postColumns + postWithDetailsJoins + `
+		WHERE u.telegram_id = $1 OR u2.telegram_id = $1
+		ORDER BY p.created_at DESC
+		LIMIT 20
+	`
+	return r.list(ctx, query, telegramID)
+}
+
+func (r *JobRepository) GetStats(ctx context.Context) (*domain.Stats, error) {
 	query := `
 		SELECT
-			p.id, p.post_type, p.user_id, p.company_id, p.title, p.level, p.type, p.category,
-			p.salary_from, p.salary_to, p.description, p.apply_link, p.status, p.language,
-			p.published_at, p.created_at, p.experience_years, p.employment, p.about, p.resume_link, p.contact,
-			COALESCE(c.name, '') as company_name,
-			COALESCE(c.contact, '') as company_contact,
-			COALESCE(u.telegram_id, u2.telegram_id) as author_telegram_id
-		FROM posts p
-		LEFT JOIN companies c ON p.company_id = c.id
-		LEFT JOIN users u ON c.user_id = u.id
-		LEFT JOIN users u2 ON p.user_id = u2.id
-		WHERE p.id = $1
+			COUNT(*) as total,
+			COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			COUNT(*) FILTER (WHERE status = 'published') as published,
+			COUNT(*) FILTER (WHERE status = 'rejected') as rejected,
+			COUNT(*) FILTER (WHERE status = 'archived') as archived
+		FROM posts
 	`
-	var post domain.PostWithDetails
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+	var stats domain.Stats
+	err := r.db.Pool.QueryRow(ctx, query).Scan(
+		&stats.Total,
+		&stats.Pending,
+		&stats.Published,
+		&stats.Rejected,
+		&stats.Archived,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// postColumns are the posts columns read by scanPost (table alias p)
+const postColumns = `
+	p.id, p.post_type, p.user_id, p.company_id, p.title, p.level, p.type, p.category,
+	p.salary_from, p.salary_to, p.description, p.apply_link, p.status, p.language,
+	p.channel_message_id, p.published_at, p.created_at, p.experience_years, p.employment, p.about, p.resume_link, p.contact,
+	COALESCE(p.status_reason, ''), p.publish_at,
+	p.tier, p.pinned_at, p.pin_until, p.unpinned_at`
+
+// postWithDetailsColumns adds company and author columns; use with postWithDetailsJoins
+const postWithDetailsColumns = postColumns + `,
+	COALESCE(c.name, '') as company_name,
+	COALESCE(c.contact, '') as company_contact,
+	COALESCE(u.telegram_id, u2.telegram_id) as author_telegram_id,
+	EXISTS (SELECT 1 FROM payments pay WHERE pay.post_id = p.id AND pay.status = 'paid') as paid,
+	COALESCE(pc.code, '') as promo_code`
+
+const postWithDetailsJoins = `
+	FROM posts p
+	LEFT JOIN companies c ON p.company_id = c.id
+	LEFT JOIN users u ON c.user_id = u.id
+	LEFT JOIN users u2 ON p.user_id = u2.id
+	LEFT JOIN promo_codes pc ON p.promo_code_id = pc.id`
+
+func postFields(post *domain.Post) []interface{} {
+	return []interface{}{
 		&post.ID,
 		&post.PostType,
 		&post.UserID,
@@ -173,6 +479,7 @@ func (r *JobRepository) GetWithCompany(ctx context.Context, id uuid.UUID) (*doma
 		&post.ApplyLink,
 		&post.Status,
 		&post.Language,
+		&post.ChannelMessageID,
 		&post.PublishedAt,
 		&post.CreatedAt,
 		&post.ExperienceYears,
@@ -180,41 +487,32 @@ func (r *JobRepository) GetWithCompany(ctx context.Context, id uuid.UUID) (*doma
 		&post.About,
 		&post.ResumeLink,
 		&post.Contact,
-		&post.CompanyName,
-		&post.CompanyContact,
-		&post.AuthorTelegramID,
-	)
-	if err != nil {
-		return nil, err
+		&post.StatusReason,
+		&post.PublishAt,
+		&post.Tier,
+		&post.PinnedAt,
+		&post.PinUntil,
+		&post.UnpinnedAt,
 	}
-	return &post, nil
 }
 
-func (r *JobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.JobStatus) error {
-	query := `UPDATE posts SET status = $1 WHERE id = $2`
-	_, err := r.db.Pool.Exec(ctx, query, status, id)
-	return err
-}
-
-func (r *JobRepository) SetPublished(ctx context.Context, id uuid.UUID, channelMessageID int) error {
-	query := `UPDATE posts SET status = $1, published_at = $2, channel_message_id = $3 WHERE id = $4`
-	_, err := r.db.Pool.Exec(ctx, query, domain.JobStatusPublished, time.Now().UTC(), channelMessageID, id)
-	return err
+func scanPost(row pgx.Row, post *domain.Post) error {
+	return row.Scan(postFields(post)...)
 }
 
-func (r *JobRepository) Archive(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE posts SET status = $1 WHERE id = $2`
-	_, err := r.db.Pool.Exec(ctx, query, domain.JobStatusArchived, id)
-	return err
+func scanPostWithDetails(row pgx.Row, post *domain.PostWithDetails) error {
+	fields := append(postFields(&post.Post),
+		&post.CompanyName,
+		&post.CompanyContact,
+		&post.AuthorTelegramID,
+		&post.Paid,
+		&post.PromoCode,
+	)
+	return row.Scan(fields...)
 }
 
-func (r *JobRepository) GetExpiredJobs(ctx context.Context, days int) ([]domain.Post, error) {
-	query := `
-		SELECT id, post_type, user_id, company_id, title, level, type, category, salary_from, salary_to, description, apply_link, status, language, channel_message_id, published_at, created_at, experience_years, employment, about, resume_link, contact
-		FROM posts
-		WHERE status = 'published' AND published_at < NOW() - INTERVAL '1 day' * $1
-	`
-	rows, err := r.db.Pool.Query(ctx, query, days)
+func (r *JobRepository) list(ctx context.Context, query string, args ...interface{}) ([]domain.Post, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -223,110 +521,28 @@ func (r *JobRepository) GetExpiredJobs(ctx context.Context, days int) ([]domain.
 	var posts []domain.Post
 	for rows.Next() {
 		var post domain.Post
-		err := rows.Scan(
-			&post.ID,
-			&post.PostType,
-			&post.UserID,
-			&post.CompanyID,
-			&post.Title,
-			&post.Level,
-			&post.Type,
-			&post.Category,
-			&post.SalaryFrom,
-			&post.SalaryTo,
-			&post.Description,
-			&post.ApplyLink,
-			&post.Status,
-			&post.Language,
-			&post.ChannelMessageID,
-			&post.PublishedAt,
-			&post.CreatedAt,
-			&post.ExperienceYears,
-			&post.Employment,
-			&post.About,
-			&post.ResumeLink,
-			&post.Contact,
-		)
-		if err != nil {
+		if err := scanPost(rows, &post); err != nil {
 			return nil, err
 		}
 		posts = append(posts, post)
 	}
-	return posts, nil
+	return posts, rows.Err()
 }
 
-func (r *JobRepository) GetByUserTelegramID(ctx context.Context, telegramID int64) ([]domain.Post, error) {
-	query := `
-		SELECT p.id, p.post_type, p.user_id, p.company_id, p.title, p.level, p.type, p.category, p.salary_from, p.salary_to, p.description, p.apply_link, p.status, p.language, p.channel_message_id, p.published_at, p.created_at, p.experience_years, p.employment, p.about, p.resume_link, p.contact
-		FROM posts p
-		LEFT JOIN companies c ON p.company_id = c.id
-		LEFT JOIN users u ON c.user_id = u.id
-		LEFT JOIN users u2 ON p.user_id = u2.id
-		WHERE u.telegram_id = $1 OR u2.telegram_id = $1
-		ORDER BY p.created_at DESC
-		LIMIT 20
-	`
-	rows, err := r.db.Pool.Query(ctx, query, telegramID)
+func (r *JobRepository) listWithDetails(ctx context.Context, query string, args ...interface{}) ([]domain.PostWithDetails, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var posts []domain.Post
+	var posts []domain.PostWithDetails
 	for rows.Next() {
-		var post domain.Post
-		err := rows.Scan(
-			&post.ID,
-			&post.PostType,
-			&post.UserID,
-			&post.CompanyID,
-			&post.Title,
-			&post.Level,
-			&post.Type,
-			&post.Category,
-			&post.SalaryFrom,
-			&post.SalaryTo,
-			&post.Description,
-			&post.ApplyLink,
-			&post.Status,
-			&post.Language,
-			&post.ChannelMessageID,
-			&post.PublishedAt,
-			&post.CreatedAt,
-			&post.ExperienceYears,
-			&post.Employment,
-			&post.About,
-			&post.ResumeLink,
-			&post.Contact,
-		)
-		if err != nil {
+		var post domain.PostWithDetails
+		if err := scanPostWithDetails(rows, &post); err != nil {
 			return nil, err
 		}
 		posts = append(posts, post)
 	}
-	return posts, nil
-}
-
-func (r *JobRepository) GetStats(ctx context.Context) (*domain.Stats, error) {
-	query := `
-		SELECT
-			COUNT(*) as total,
-			COUNT(*) FILTER (WHERE status = 'pending') as pending,
-			COUNT(*) FILTER (WHERE status = 'published') as published,
-			COUNT(*) FILTER (WHERE status = 'rejected') as rejected,
-			COUNT(*) FILTER (WHERE status = 'archived') as archived
-		FROM posts
-	`
-	var stats domain.Stats
-	err := r.db.Pool.QueryRow(ctx, query).Scan(
-		&stats.Total,
-		&stats.Pending,
-		&stats.Published,
-		&stats.Rejected,
-		&stats.Archived,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return &stats, nil
+	return posts, rows.Err()
 }
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type PackageRepository struct {
+	db *DB
+}
+
+func NewPackageRepository(db *DB) *PackageRepository {
+	return &PackageRepository{db: db}
+}
+
+const packageColumns = `id, user_id, total_credits, used_credits, expires_at, granted_by, created_at`
+
+func (r *PackageRepository) Create(ctx context.Context, pkg *domain.Package) error {
+	query := `
+		INSERT INTO packages (id, user_id, total_credits, used_credits, expires_at, granted_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	pkg.ID = uuid.New()
+	return r.db.Pool.QueryRow(ctx, query,
+		pkg.ID,
+		pkg.UserID,
+		pkg.TotalCredits,
+		pkg.UsedCredits,
+		pkg.ExpiresAt,
+		pkg.GrantedBy,
+	).Scan(&pkg.CreatedAt)
+}
+
+func (r *PackageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Package, error) {
+	query := `SELECT ` + packageColumns + ` FROM packages WHERE id = $1`
+	return scanPackage(r.db.Pool.QueryRow(ctx, query, id))
+}
+
+// ListByUser returns all packages of a user, newest first
+func (r *PackageRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Package, error) {
+	query := `SELECT ` + packageColumns + ` FROM packages WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var packages []domain.Package
+	for rows.Next() {
+		pkg, err := scanPackage(rows)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, *pkg)
+	}
+	return packages, rows.Err()
+}
+
+// Adjust changes the total credits by delta and, if expiresAt is set, the expiry.
+// It returns false if the package doesn't exist or would end up with fewer
+// credits than already used.
+func (r *PackageRepository) Adjust(ctx context.Context, id uuid.UUID, delta int, expiresAt *time.Time) (bool, error) {
+	query := `
+		UPDATE packages
+		SET total_credits = total_credits + $1, expires_at = COALESCE($2, expires_at)
+		WHERE id = $3 AND total_credits + $1 >= used_credits
+	`
+	tag, err := r.db.Pool.Exec(ctx, query, delta, expiresAt, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// usePackageCredit takes one credit from the author's package that expires first
+// for a standard post and reports whether it did. It does nothing if the author
+// has no usable package, the post was already charged, or it is paid for by a
+// payment (invoice, promo).
+func usePackageCredit(ctx context.Context, tx pgx.Tx, postID uuid.UUID) (bool, error) {
+	query := `
+		WITH pkg AS (
+			SELECT pk.id FROM packages pk
+			JOIN posts p ON p.user_id = pk.user_id
+			WHERE p.id = $1 AND p.tier = 'standard'
+			  AND pk.used_credits < pk.total_credits
+			  AND (pk.expires_at IS NULL OR pk.expires_at > now())
+			  AND NOT EXISTS (SELECT 1 FROM package_usages u WHERE u.post_id = $1)
+			  AND NOT EXISTS (SELECT 1 FROM payments pay WHERE pay.post_id = $1 AND pay.status = 'paid')
+			ORDER BY pk.expires_at NULLS LAST, pk.created_at
+			LIMIT 1
+			FOR UPDATE OF pk
+		), used AS (
+			UPDATE packages SET used_credits = used_credits + 1
+			WHERE id IN (SELECT id FROM pkg) AND used_credits < total_credits
+			RETURNING id
+		)
+		INSERT INTO package_usages (post_id, package_id)
+		SELECT $1, id FROM used
+		ON CONFLICT (post_id) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, query, postID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func scanPackage(row pgx.Row) (*domain.Package, error) {
+	var pkg domain.Package
+	err := row.Scan(
+		&pkg.ID,
+		&pkg.UserID,
+		&pkg.TotalCredits,
+		&pkg.UsedCredits,
+		&pkg.ExpiresAt,
+		&pkg.GrantedBy,
+		&pkg.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
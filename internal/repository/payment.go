@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type PaymentRepository struct {
+	db *DB
+}
+
+func NewPaymentRepository(db *DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+const paymentColumns = `id, company_id, post_id, amount, currency, method, type, tier, status, recorded_by, COALESCE(external_id, ''), promo_code_id, discount, paid_at, created_at`
+
+func (r *PaymentRepository) Create(ctx context.Context, payment *domain.Payment) error {
+	query := `
+		INSERT INTO payments (id, company_id, post_id, amount, currency, method, type, tier, status, recorded_by, external_id, promo_code_id, discount, paid_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), $12, $13, $14)
+		RETURNING created_at
+	`
+	payment.ID = uuid.New()
+	if payment.Status == "" {
+		payment.Status = domain.PaymentStatusPending
+	}
+	return r.db.Pool.QueryRow(ctx, query,
+		payment.ID,
+		payment.CompanyID,
+		payment.PostID,
+		payment.Amount,
+		payment.Currency,
+		payment.Method,
+		payment.Type,
+		payment.Tier,
+		payment.Status,
+		payment.RecordedBy,
+		payment.ExternalID,
+		payment.PromoCodeID,
+		payment.Discount,
+		payment.PaidAt,
+	).Scan(&payment.CreatedAt)
+}
+
+func (r *PaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = $1`
+	return scanPayment(r.db.Pool.QueryRow(ctx, query, id))
+}
+
+// GetByExternalID finds a payment by its Telegram charge ID
+func (r *PaymentRepository) GetByExternalID(ctx context.Context, externalID string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE external_id = $1`
+	return scanPayment(r.db.Pool.QueryRow(ctx, query, externalID))
+}
+
+func (r *PaymentRepository) ListByPost(ctx context.Context, postID uuid.UUID) ([]domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE post_id = $1 ORDER BY created_at`
+	rows, err := r.db.Pool.Query(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []domain.Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, *payment)
+	}
+	return payments, rows.Err()
+}
+
+// SetStatus settles a pending payment as paid or failed. It returns false if
+// the payment was already settled.
+func (r *PaymentRepository) SetStatus(ctx context.Context, id uuid.UUID, status domain.PaymentStatus) (bool, error) {
+	var paidAt *time.Time
+	if status == domain.PaymentStatusPaid {
+		now := time.Now().UTC()
+		paidAt = &now
+	}
+
+	query := `UPDATE payments SET status = $1, paid_at = $2 WHERE id = $3 AND status = 'pending'`
+	tag, err := r.db.Pool.Exec(ctx, query, status, paidAt, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// HasPaidForPost reports whether a paid payment covering tier is linked to the
+// post. A payment without a tier covers any tier, a featured one also covers standard.
+func (r *PaymentRepository) HasPaidForPost(ctx context.Context, postID uuid.UUID, tier domain.PlacementTier) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM payments
+			WHERE post_id = $1 AND status = 'paid'
+				AND (tier IS NULL OR tier = $2 OR $2 = 'standard')
+		)
+	`
+	var paid bool
+	err := r.db.Pool.QueryRow(ctx, query, postID, tier).Scan(&paid)
+	return paid, err
+}
+
+func scanPayment(row pgx.Row) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := row.Scan(
+		&payment.ID,
+		&payment.CompanyID,
+		&payment.PostID,
+		&payment.Amount,
+		&payment.Currency,
+		&payment.Method,
+		&payment.Type,
+		&payment.Tier,
+		&payment.Status,
+		&payment.RecordedBy,
+		&payment.ExternalID,
+		&payment.PromoCodeID,
+		&payment.Discount,
+		&payment.PaidAt,
+		&payment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
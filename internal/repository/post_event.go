@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type PostEventRepository struct {
+	db *DB
+}
+
+func NewPostEventRepository(db *DB) *PostEventRepository {
+	return &PostEventRepository{db: db}
+}
+
+// ListByPost returns the status history of a post, oldest first
+func (r *PostEventRepository) ListByPost(ctx context.Context, postID uuid.UUID) ([]domain.PostEvent, error) {
+	query := `
+		SELECT id, post_id, actor_telegram_id, from_status, to_status, reason, created_at
+		FROM post_events
+		WHERE post_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.Pool.Query(ctx, query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.PostEvent
+	for rows.Next() {
+		var event domain.PostEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.PostID,
+			&event.ActorTelegramID,
+			&event.FromStatus,
+			&event.ToStatus,
+			&event.Reason,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// insertPostEvent writes an event inside the transaction that changes the status
+func insertPostEvent(ctx context.Context, tx pgx.Tx, event *domain.PostEvent) error {
+	query := `
+		INSERT INTO post_events (id, post_id, actor_telegram_id, from_status, to_status, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	event.ID = uuid.New()
+	return tx.QueryRow(ctx, query,
+		event.ID,
+		event.PostID,
+		event.ActorTelegramID,
+		event.FromStatus,
+		event.ToStatus,
+		event.Reason,
+	).Scan(&event.CreatedAt)
+}
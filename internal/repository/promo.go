@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type PromoCodeRepository struct {
+	db *DB
+}
+
+func NewPromoCodeRepository(db *DB) *PromoCodeRepository {
+	return &PromoCodeRepository{db: db}
+}
+
+const promoCodeColumns = `pc.id, pc.code, pc.kind, pc.value, pc.tier, pc.max_uses, pc.used_count, pc.expires_at, pc.disabled_at, pc.created_by, pc.created_at`
+
+func (r *PromoCodeRepository) Create(ctx context.Context, promo *domain.PromoCode) error {
+	query := `
+		INSERT INTO promo_codes (id, code, kind, value, tier, max_uses, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`
+	promo.ID = uuid.New()
+	return r.db.Pool.QueryRow(ctx, query,
+		promo.ID,
+		promo.Code,
+		promo.Kind,
+		promo.Value,
+		promo.Tier,
+		promo.MaxUses,
+		promo.ExpiresAt,
+		promo.CreatedBy,
+	).Scan(&promo.CreatedAt)
+}
+
+func (r *PromoCodeRepository) GetByCode(ctx context.Context, code string) (*domain.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes pc WHERE pc.code = $1`
+	return scanPromoCode(r.db.Pool.QueryRow(ctx, query, code))
+}
+
+// GetForPost returns the promo code redeemed by a post
+func (r *PromoCodeRepository) GetForPost(ctx context.Context, postID uuid.UUID) (*domain.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + `
+		FROM promo_codes pc
+		JOIN posts p ON p.promo_code_id = pc.id
+		WHERE p.id = $1
+	`
+	return scanPromoCode(r.db.Pool.QueryRow(ctx, query, postID))
+}
+
+// List returns all promo codes, newest first
+func (r *PromoCodeRepository) List(ctx context.Context) ([]domain.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes pc ORDER BY pc.created_at DESC`
+	rows, err := r.db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promos []domain.PromoCode
+	for rows.Next() {
+		promo, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		promos = append(promos, *promo)
+	}
+	return promos, rows.Err()
+}
+
+// Disable stops a code from being redeemed. It returns false if the code
+// doesn't exist or is already disabled.
+func (r *PromoCodeRepository) Disable(ctx context.Context, code string) (bool, error) {
+	query := `UPDATE promo_codes SET disabled_at = now() WHERE code = $1 AND disabled_at IS NULL`
+	tag, err := r.db.Pool.Exec(ctx, query, code)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Redeem counts one use of the code and links it to the post. It returns
+// false if the code ran out of uses, expired or was disabled in the meantime.
+func (r *PromoCodeRepository) Redeem(ctx context.Context, promoID uuid.UUID, postID uuid.UUID) (bool, error) {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE promo_codes SET used_count = used_count + 1
+		WHERE id = $1 AND disabled_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > now())
+		  AND (max_uses IS NULL OR used_count < max_uses)
+	`
+	tag, err := tx.Exec(ctx, query, promoID)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() != 1 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE posts SET promo_code_id = $1 WHERE id = $2`, promoID, postID); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+func scanPromoCode(row pgx.Row) (*domain.PromoCode, error) {
+	var promo domain.PromoCode
+	err := row.Scan(
+		&promo.ID,
+		&promo.Code,
+		&promo.Kind,
+		&promo.Value,
+		&promo.Tier,
+		&promo.MaxUses,
+		&promo.UsedCount,
+		&promo.ExpiresAt,
+		&promo.DisabledAt,
+		&promo.CreatedBy,
+		&promo.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
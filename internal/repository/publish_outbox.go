@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type PublishOutboxRepository struct {
+	db *DB
+}
+
+func NewPublishOutboxRepository(db *DB) *PublishOutboxRepository {
+	return &PublishOutboxRepository{db: db}
+}
+
+// ClaimNext takes the oldest due task and hides it from other workers until
+// now+lease, so a crashed worker's task is picked up again later.
+// It returns nil when nothing is due.
+func (r *PublishOutboxRepository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (*domain.PublishTask, error) {
+	query := `
+		UPDATE publish_outbox SET next_attempt_at = $1
+		WHERE id = (
+			SELECT id FROM publish_outbox
+			WHERE next_attempt_at <= $2
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, post_id, actor_telegram_id, attempts, last_error, channel_message_id, created_at
+	`
+	var task domain.PublishTask
+	err := r.db.Pool.QueryRow(ctx, query, now.Add(lease), now).Scan(
+		&task.ID,
+		&task.PostID,
+		&task.ActorTelegramID,
+		&task.Attempts,
+		&task.LastError,
+		&task.ChannelMessageID,
+		&task.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// Done removes a task once its post is published
+func (r *PublishOutboxRepository) Done(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM publish_outbox WHERE id = $1`
+	_, err := r.db.Pool.Exec(ctx, query, id)
+	return err
+}
+
+// Retry records a failed attempt and schedules the next one
+func (r *PublishOutboxRepository) Retry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE publish_outbox SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2 WHERE id = $3`
+	_, err := r.db.Pool.Exec(ctx, query, nextAttemptAt, lastError, id)
+	return err
+}
+
+// Postpone moves the next attempt without counting a failed one, for when
+// Telegram only asked us to wait
+func (r *PublishOutboxRepository) Postpone(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	query := `UPDATE publish_outbox SET next_attempt_at = $1, last_error = $2 WHERE id = $3`
+	_, err := r.db.Pool.Exec(ctx, query, nextAttemptAt, lastError, id)
+	return err
+}
+
+// SetMessageID records the channel message sent for a task before the post
+// is marked published, so a retry doesn't send it again
+func (r *PublishOutboxRepository) SetMessageID(ctx context.Context, id uuid.UUID, channelMessageID int) error {
+	query := `UPDATE publish_outbox SET channel_message_id = $1 WHERE id = $2`
+	_, err := r.db.Pool.Exec(ctx, query, channelMessageID, id)
+	return err
+}
+
+// Fail records the last attempt and stops retrying the task
+func (r *PublishOutboxRepository) Fail(ctx context.Context, id uuid.UUID, lastError string) error {
+	query := `UPDATE publish_outbox SET attempts = attempts + 1, next_attempt_at = NULL, last_error = $1 WHERE id = $2`
+	_, err := r.db.Pool.Exec(ctx, query, lastError, id)
+	return err
+}
+
+// Requeue restarts a task that ran out of attempts, keeping a channel message
+// it may already have sent. It returns false if the post has no failed task.
+func (r *PublishOutboxRepository) Requeue(ctx context.Context, postID uuid.UUID, actorTelegramID *int64, at time.Time) (bool, error) {
+	query := `
+		UPDATE publish_outbox
+		SET actor_telegram_id = $1, attempts = 0, next_attempt_at = $2, last_error = ''
+		WHERE post_id = $3 AND next_attempt_at IS NULL
+	`
+	result, err := r.db.Pool.Exec(ctx, query, actorTelegramID, at, postID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// insertPublishTask queues a post inside the transaction that approves it
+func insertPublishTask(ctx context.Context, tx pgx.Tx, postID uuid.UUID, actorTelegramID *int64, at time.Time) error {
+	query := `
+		INSERT INTO publish_outbox (id, post_id, actor_telegram_id, next_attempt_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id) DO UPDATE
+		SET actor_telegram_id = EXCLUDED.actor_telegram_id,
+			attempts = 0,
+			next_attempt_at = EXCLUDED.next_attempt_at,
+			last_error = '',
+			channel_message_id = NULL
+	`
+	_, err := tx.Exec(ctx, query, uuid.New(), postID, actorTelegramID, at)
+	return err
+}
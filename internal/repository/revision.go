@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+)
+
+type RevisionRepository struct {
+	db *DB
+}
+
+func NewRevisionRepository(db *DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+// Create saves a new pending revision and marks the post's older pending
+// ones superseded, so only the latest edit can be approved
+func (r *RevisionRepository) Create(ctx context.Context, revision *domain.PostRevision) error {
+	content, err := json.Marshal(revision.Content)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the post so concurrent edits supersede each other in order
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM posts WHERE id = $1 FOR UPDATE`, revision.PostID); err != nil {
+		return err
+	}
+	supersede := `UPDATE post_revisions SET status = $1, reviewed_at = $2 WHERE post_id = $3 AND status = 'pending'`
+	if _, err := tx.Exec(ctx, supersede, domain.RevisionStatusSuperseded, time.Now().UTC(), revision.PostID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO post_revisions (id, post_id, content, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	revision.ID = uuid.New()
+	revision.Status = domain.RevisionStatusPending
+	err = tx.QueryRow(ctx, query,
+		revision.ID,
+		revision.PostID,
+		content,
+		revision.Status,
+	).Scan(&revision.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *RevisionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PostRevision, error) {
+	query := `
+		SELECT id, post_id, content, status, created_at, reviewed_at
+		FROM post_revisions
+		WHERE id = $1
+	`
+	var revision domain.PostRevision
+	var content []byte
+	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+		&revision.ID,
+		&revision.PostID,
+		&content,
+		&revision.Status,
+		&revision.CreatedAt,
+		&revision.ReviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &revision.Content); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// Review moves a pending revision to the given status. It returns false if the
+// revision was already reviewed (e.g. by another admin).
+func (r *RevisionRepository) Review(ctx context.Context, id uuid.UUID, status domain.RevisionStatus) (bool, error) {
+	query := `UPDATE post_revisions SET status = $1, reviewed_at = $2 WHERE id = $3 AND status = 'pending'`
+	tag, err := r.db.Pool.Exec(ctx, query, status, time.Now().UTC(), id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Approve marks a pending revision approved and writes the post content
+// (and company) taken from it in one transaction. It returns false if the
+// revision was already reviewed.
+func (r *RevisionRepository) Approve(ctx context.Context, id uuid.UUID, post *domain.PostWithDetails) (bool, error) {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE post_revisions SET status = $1, reviewed_at = $2 WHERE id = $3 AND status = 'pending'`
+	tag, err := tx.Exec(ctx, query, domain.RevisionStatusApproved, time.Now().UTC(), id)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() != 1 {
+		return false, nil
+	}
+
+	if post.CompanyID != nil {
+		query := `UPDATE companies SET name = $1, contact = $2 WHERE id = $3`
+		if _, err := tx.Exec(ctx, query, post.CompanyName, post.CompanyContact, *post.CompanyID); err != nil {
+			return false, err
+		}
+	}
+	if _, err := tx.Exec(ctx, updatePostContentQuery, postContentArgs(&post.Post)...); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"telegram-job/internal/domain"
+)
+
+type SubscriptionRepository struct {
+	db *DB
+}
+
+func NewSubscriptionRepository(db *DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+const subscriptionColumns = `id, telegram_id, post_type, level, type, category, min_salary, keywords, frequency, last_digest_at, created_at`
+
+func (r *SubscriptionRepository) Create(ctx context.Context, sub *domain.Subscription) error {
+	query := `
+		INSERT INTO subscriptions (id, telegram_id, post_type, level, type, category, min_salary, keywords, frequency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at
+	`
+	sub.ID = uuid.New()
+	if sub.Keywords == nil {
+		sub.Keywords = []string{}
+	}
+	if sub.Frequency == "" {
+		sub.Frequency = domain.AlertInstant
+	}
+	return r.db.Pool.QueryRow(ctx, query,
+		sub.ID,
+		sub.TelegramID,
+		sub.PostType,
+		sub.Level,
+		sub.Type,
+		sub.Category,
+		sub.MinSalary,
+		sub.Keywords,
+		sub.Frequency,
+	).Scan(&sub.CreatedAt)
+}
+
+// ListByTelegramID returns the subscriptions of a user, oldest first
+func (r *SubscriptionRepository) ListByTelegramID(ctx context.Context, telegramID int64) ([]domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE telegram_id = $1 ORDER BY created_at`
+	rows, err := r.db.Pool.Query(ctx, query, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []domain.Subscription
+	for rows.Next() {
+		var sub domain.Subscription
+		err := rows.Scan(
+			&sub.ID,
+			&sub.TelegramID,
+			&sub.PostType,
+			&sub.Level,
+			&sub.Type,
+			&sub.Category,
+			&sub.MinSalary,
+			&sub.Keywords,
+			&sub.Frequency,
+			&sub.LastDigestAt,
+			&sub.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (r *SubscriptionRepository) CountByTelegramID(ctx context.Context, telegramID int64) (int, error) {
+	var count int
+	err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM subscriptions WHERE telegram_id = $1`, telegramID).Scan(&count)
+	return count, err
+}
+
+// Delete removes a subscription of the given user. It returns false if there was none.
+func (r *SubscriptionRepository) Delete(ctx context.Context, telegramID int64, id uuid.UUID) (bool, error) {
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM subscriptions WHERE id = $1 AND telegram_id = $2`, id, telegramID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// DeleteByTelegramID removes all subscriptions and pending alerts of a user
+// (e.g. after they blocked the bot)
+func (r *SubscriptionRepository) DeleteByTelegramID(ctx context.Context, telegramID int64) error {
+	if _, err := r.db.Pool.Exec(ctx, `DELETE FROM job_alerts WHERE telegram_id = $1`, telegramID); err != nil {
+		return err
+	}
+	_, err := r.db.Pool.Exec(ctx, `DELETE FROM subscriptions WHERE telegram_id = $1`, telegramID)
+	return err
+}
+
+// ClaimAlerts takes up to limit queued alerts, oldest first, and hides them
+// from other workers until now+lease
+func (r *SubscriptionRepository) ClaimAlerts(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.JobAlert, error) {
+	query := `
+		UPDATE job_alerts SET claimed_until = $1
+		WHERE id IN (
+			SELECT id FROM job_alerts
+			WHERE claimed_until IS NULL OR claimed_until <= $2
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, telegram_id, post_id
+	`
+	rows, err := r.db.Pool.Query(ctx, query, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []domain.JobAlert
+	for rows.Next() {
+		var alert domain.JobAlert
+		if err := rows.Scan(&alert.ID, &alert.TelegramID, &alert.PostID); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// AlertDone removes a sent (or no longer relevant) alert
+func (r *SubscriptionRepository) AlertDone(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.Pool.Exec(ctx, `DELETE FROM job_alerts WHERE id = $1`, id)
+	return err
+}
+
+// subscriptionMatches is the filter of subscription s against post p
+const subscriptionMatches = `
+	(s.post_type IS NULL OR s.post_type = p.post_type)
+	AND (s.level IS NULL OR s.level = p.level)
+	AND (s.type IS NULL OR s.type = p.type)
+	AND (s.category IS NULL OR s.category = p.category)
+	AND (s.min_salary IS NULL OR COALESCE(p.salary_to, p.salary_from) >= s.min_salary)
+	AND (cardinality(s.keywords) = 0 OR EXISTS (
+		SELECT 1 FROM unnest(s.keywords) k
+		WHERE position(k IN lower(p.title || ' ' || COALESCE(p.description, '') || ' ' || COALESCE(p.about, ''))) > 0
+	))`
+
+// ClaimDigests takes up to limit users with a digest of the given frequency
+// due for the period ending at periodEnd and marks their subscriptions as
+// sent, so each user is claimed once per period
+func (r *SubscriptionRepository) ClaimDigests(ctx context.Context, frequency domain.AlertFrequency, periodEnd time.Time, limit int) ([]int64, error) {
+	query := `
+		WITH due AS (
+			SELECT DISTINCT telegram_id FROM subscriptions
+			WHERE frequency = $1 AND COALESCE(last_digest_at, created_at) < $2
+			LIMIT $3
+		), claimed AS (
+			UPDATE subscriptions s SET last_digest_at = $2
+			FROM due
+			WHERE s.telegram_id = due.telegram_id AND s.frequency = $1
+				AND COALESCE(s.last_digest_at, s.created_at) < $2
+			RETURNING s.telegram_id
+		)
+		SELECT DISTINCT telegram_id FROM claimed
+	`
+	rows, err := r.db.Pool.Query(ctx, query, frequency, periodEnd, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var telegramIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		telegramIDs = append(telegramIDs, id)
+	}
+	return telegramIDs, rows.Err()
+}
+
+// ListDigestPosts returns posts published in [from, to) that match any of the
+// user's subscriptions of the given frequency, oldest first
+func (r *SubscriptionRepository) ListDigestPosts(ctx context.Context, telegramID int64, frequency domain.AlertFrequency, from, to time.Time) ([]domain.PostWithDetails, error) {
+	query := `SELECT ` + postWithDetailsColumns + postWithDetailsJoins + `
+		WHERE p.status = 'published' AND p.channel_message_id IS NOT NULL
+			AND p.published_at >= $3 AND p.published_at < $4
+			AND u2.telegram_id IS DISTINCT FROM $1
+			AND EXISTS (
+				SELECT 1 FROM subscriptions s
+				WHERE s.telegram_id = $1 AND s.frequency = $2 AND ` + subscriptionMatches + `
+			)
+		ORDER BY p.published_at
+	`
+	rows, err := r.db.Pool.Query(ctx, query, telegramID, frequency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []domain.PostWithDetails
+	for rows.Next() {
+		var post domain.PostWithDetails
+		if err := scanPostWithDetails(rows, &post); err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+// insertJobAlerts queues one alert per instant subscriber whose filters match
+// the post. Matching is a single set-based query, so it stays cheap with
+// thousands of subscriptions. The author doesn't get alerts about their own post.
+func insertJobAlerts(ctx context.Context, tx pgx.Tx, postID uuid.UUID) error {
+	query := `
+		INSERT INTO job_alerts (telegram_id, post_id)
+		SELECT DISTINCT s.telegram_id, p.id
+		FROM posts p
+		JOIN subscriptions s ON s.frequency = 'instant' AND ` + subscriptionMatches + `
+		LEFT JOIN users u ON u.id = p.user_id
+		WHERE p.id = $1 AND s.telegram_id IS DISTINCT FROM u.telegram_id
+		ON CONFLICT (telegram_id, post_id) DO NOTHING
+	`
+	_, err := tx.Exec(ctx, query, postID)
+	return err
+}
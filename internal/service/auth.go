@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/auth"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+const (
+	apiKeyPrefix = "tj_"
+	// lastUsedResolution limits last_used_at writes to one per key per minute
+	lastUsedResolution = time.Minute
+)
+
+var (
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
+
+// AuthService turns credentials of HTTP API callers into a verified
+// domain.Principal: signed Mini App initData or issued API keys
+type AuthService struct {
+	cfg        *config.Config
+	apiKeyRepo *repository.APIKeyRepository
+}
+
+func NewAuthService(cfg *config.Config, apiKeyRepo *repository.APIKeyRepository) *AuthService {
+	return &AuthService{
+		cfg:        cfg,
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+func (s *AuthService) AuthenticateInitData(initData string) (*domain.Principal, error) {
+	maxAge := time.Duration(s.cfg.InitDataMaxAgeHours) * time.Hour
+	user, err := auth.VerifyInitData(initData, s.cfg.BotToken, maxAge, time.Now())
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	return &domain.Principal{
+		Kind:       domain.PrincipalWebApp,
+		TelegramID: user.ID,
+		Username:   user.Username,
+	}, nil
+}
+
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (*domain.Principal, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrUnauthorized
+	}
+
+	apiKey, err := s.apiKeyRepo.GetActiveByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil {
+		return nil, ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) > lastUsedResolution {
+		if err := s.apiKeyRepo.SetLastUsed(ctx, apiKey.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.Principal{
+		Kind:       domain.PrincipalAPIKey,
+		TelegramID: apiKey.TelegramID,
+		APIKeyID:   &apiKey.ID,
+	}, nil
+}
+
+// CreateAPIKey issues a key acting as telegramID. The plain key is returned
+// only here; afterwards just its hash is known.
+func (s *AuthService) CreateAPIKey(ctx context.Context, adminTelegramID, telegramID int64, name string) (string, *domain.APIKey, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return "", nil, ErrForbidden
+	}
+
+	name = strings.TrimSpace(name)
+	if telegramID <= 0 || name == "" {
+		return "", nil, ErrInvalidAPIKey
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := &domain.APIKey{
+		Name:       name,
+		TelegramID: telegramID,
+		CreatedBy:  adminTelegramID,
+	}
+	if err := s.apiKeyRepo.Create(ctx, apiKey, hashAPIKey(key)); err != nil {
+		return "", nil, err
+	}
+	return key, apiKey, nil
+}
+
+func (s *AuthService) RevokeAPIKey(ctx context.Context, adminTelegramID int64, id uuid.UUID) error {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return ErrForbidden
+	}
+
+	revoked, err := s.apiKeyRepo.Revoke(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *AuthService) ListAPIKeys(ctx context.Context, adminTelegramID int64) ([]domain.APIKey, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return nil, ErrForbidden
+	}
+	return s.apiKeyRepo.List(ctx)
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
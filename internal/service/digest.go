@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+// Digest is the summary of one period for one subscriber
+type Digest struct {
+	TelegramID int64
+	Frequency  domain.AlertFrequency
+	From, To   time.Time
+	Posts      []domain.PostWithDetails
+}
+
+// DigestService compiles daily and weekly digests for subscribers and the
+// weekly digest of the channel
+type DigestService struct {
+	cfg       *config.Config
+	subRepo   *repository.SubscriptionRepository
+	jobRepo   *repository.JobRepository
+	publisher Publisher
+}
+
+func NewDigestService(cfg *config.Config, subRepo *repository.SubscriptionRepository, jobRepo *repository.JobRepository, publisher Publisher) *DigestService {
+	return &DigestService{
+		cfg:       cfg,
+		subRepo:   subRepo,
+		jobRepo:   jobRepo,
+		publisher: publisher,
+	}
+}
+
+// DigestPeriod returns the last complete digest period before now. Periods end
+// at cfg.DigestHour UTC, every day for daily digests and on Mondays for weekly ones.
+func (s *DigestService) DigestPeriod(frequency domain.AlertFrequency, now time.Time) (from, to time.Time) {
+	now = now.UTC()
+	to = time.Date(now.Year(), now.Month(), now.Day(), s.cfg.DigestHour, 0, 0, 0, time.UTC)
+
+	if frequency == domain.AlertWeekly {
+		to = to.AddDate(0, 0, -((int(to.Weekday()) + 6) % 7)) // back to Monday
+		if to.After(now) {
+			to = to.AddDate(0, 0, -7)
+		}
+		return to.AddDate(0, 0, -7), to
+	}
+
+	if to.After(now) {
+		to = to.AddDate(0, 0, -1)
+	}
+	return to.AddDate(0, 0, -1), to
+}
+
+// ClaimDigests takes up to limit subscribers whose digest for the current
+// period hasn't been sent yet and collects the matching posts. Digests with
+// no posts are returned too, so callers can tell when the queue is drained.
+func (s *DigestService) ClaimDigests(ctx context.Context, frequency domain.AlertFrequency, limit int) ([]Digest, error) {
+	from, to := s.DigestPeriod(frequency, time.Now())
+
+	telegramIDs, err := s.subRepo.ClaimDigests(ctx, frequency, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	digests := make([]Digest, 0, len(telegramIDs))
+	for _, telegramID := range telegramIDs {
+		posts, err := s.subRepo.ListDigestPosts(ctx, telegramID, frequency, from, to)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, Digest{
+			TelegramID: telegramID,
+			Frequency:  frequency,
+			From:       from,
+			To:         to,
+			Posts:      posts,
+		})
+	}
+	return digests, nil
+}
+
+// PublishChannelDigest posts last week's digest to the channel once per week
+// if cfg.ChannelWeeklyDigest is on. It reports whether a digest was posted.
+func (s *DigestService) PublishChannelDigest(ctx context.Context) (bool, error) {
+	if !s.cfg.ChannelWeeklyDigest {
+		return false, nil
+	}
+
+	from, to := s.DigestPeriod(domain.AlertWeekly, time.Now())
+
+	claimed, err := s.jobRepo.ClaimChannelDigest(ctx, from)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	posts, err := s.jobRepo.ListPublishedBetween(ctx, from, to)
+	if err != nil || len(posts) == 0 {
+		return false, err
+	}
+
+	messageID, err := s.publisher.PublishDigest(ctx, posts, from, to)
+	if err != nil {
+		// Let the next run try again
+		if releaseErr := s.jobRepo.ReleaseChannelDigest(ctx, from); releaseErr != nil {
+			return false, releaseErr
+		}
+		return false, err
+	}
+
+	return true, s.jobRepo.SetChannelDigestMessage(ctx, from, messageID)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+const maxDraftsPerUser = 20
+
+var (
+	ErrTooManyDrafts   = errors.New("too many drafts")
+	ErrDraftSubmitted  = errors.New("draft already submitted")
+	ErrInvalidPostType = errors.New("invalid post type")
+)
+
+// DraftService keeps Mini App forms between sessions. Submitting a draft goes
+// through JobService, so the post is moderated like one sent from the chat.
+type DraftService struct {
+	draftRepo  *repository.DraftRepository
+	jobService *JobService
+}
+
+func NewDraftService(draftRepo *repository.DraftRepository, jobService *JobService) *DraftService {
+	return &DraftService{draftRepo: draftRepo, jobService: jobService}
+}
+
+func (s *DraftService) Create(ctx context.Context, telegramID int64, postType domain.PostType, content domain.DraftContent) (*domain.Draft, error) {
+	if postType != domain.PostTypeVacancy && postType != domain.PostTypeResume {
+		return nil, ErrInvalidPostType
+	}
+
+	count, err := s.draftRepo.CountOpen(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxDraftsPerUser {
+		return nil, ErrTooManyDrafts
+	}
+
+	draft := &domain.Draft{
+		TelegramID: telegramID,
+		PostType:   postType,
+		Content:    content,
+	}
+	if err := s.draftRepo.Create(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// Get returns a draft of the user; drafts of others are reported as missing
+func (s *DraftService) Get(ctx context.Context, telegramID int64, id uuid.UUID) (*domain.Draft, error) {
+	draft, err := s.draftRepo.GetByID(ctx, id)
+	if err != nil || draft.TelegramID != telegramID {
+		return nil, ErrNotFound
+	}
+	return draft, nil
+}
+
+func (s *DraftService) List(ctx context.Context, telegramID int64) ([]domain.Draft, error) {
+	return s.draftRepo.ListOpen(ctx, telegramID)
+}
+
+// Update replaces the whole content of an open draft
+func (s *DraftService) Update(ctx context.Context, telegramID int64, id uuid.UUID, content domain.DraftContent) (*domain.Draft, error) {
+	draft, err := s.Get(ctx, telegramID, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.SubmittedAt != nil {
+		return nil, ErrDraftSubmitted
+	}
+
+	draft.Content = content
+	updated, err := s.draftRepo.UpdateContent(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrDraftSubmitted
+	}
+	return draft, nil
+}
+
+func (s *DraftService) Delete(ctx context.Context, telegramID int64, id uuid.UUID) error {
+	if _, err := s.Get(ctx, telegramID, id); err != nil {
+		return err
+	}
+	return s.draftRepo.Delete(ctx, id)
+}
+
+// Submit validates the draft and creates a pending post from it. A draft is
+// submitted once; it stays open if creating the post fails.
+func (s *DraftService) Submit(ctx context.Context, telegramID int64, username string, id uuid.UUID) (*domain.Post, error) {
+	draft, err := s.Get(ctx, telegramID, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.SubmittedAt != nil {
+		return nil, ErrDraftSubmitted
+	}
+	if err := draft.Validate(); err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.draftRepo.ClaimSubmit(ctx, draft.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrDraftSubmitted
+	}
+
+	var post *domain.Post
+	if draft.PostType == domain.PostTypeResume {
+		post, err = s.jobService.CreateResume(ctx, telegramID, username, draft.Content.ResumeRequest())
+	} else {
+		post, err = s.jobService.CreateJob(ctx, telegramID, username, draft.Content.JobRequest())
+	}
+	if err != nil {
+		if releaseErr := s.draftRepo.ReleaseSubmit(ctx, draft.ID); releaseErr != nil {
+			return nil, releaseErr
+		}
+		if err == ErrInvalidPromoCode {
+			v := &domain.ValidationError{}
+			v.Add("promo_code", domain.CodeInvalid, "is invalid or expired")
+			return nil, v
+		}
+		return nil, err
+	}
+
+	if err := s.draftRepo.SetPost(ctx, draft.ID, post.ID); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
@@ -3,35 +3,53 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"telegram-job/internal/config"
 	"telegram-job/internal/domain"
 	"telegram-job/internal/repository"
 )
 
 var (
-	ErrForbidden       = errors.New("forbidden")
-	ErrInvalidTransition = errors.New("invalid status transition")
-	ErrNotFound        = errors.New("job not found")
+	ErrForbidden         = errors.New("forbidden")
+	ErrInvalidTransition = domain.ErrInvalidTransition
+	ErrNotFound          = errors.New("job not found")
 )
 
 type Publisher interface {
 	Publish(ctx context.Context, post *domain.PostWithDetails) (int, error)
+	Edit(ctx context.Context, messageID int, post *domain.PostWithDetails) error
+	MarkClosed(ctx context.Context, messageID int, post *domain.PostWithDetails) error
+	Pin(ctx context.Context, messageID int) error
+	Unpin(ctx context.Context, messageID int) error
 	Delete(ctx context.Context, messageID int) error
+	PublishDigest(ctx context.Context, posts []domain.PostWithDetails, from, to time.Time) (int, error)
 }
 
 type AdminNotifier interface {
 	NotifyNewJob(ctx context.Context, post *domain.PostWithDetails) error
+	NotifyRevision(ctx context.Context, revision *domain.PostRevision) error
+	NotifyPostClosed(ctx context.Context, post *domain.PostWithDetails) error
+	NotifyPublishFailed(ctx context.Context, post *domain.PostWithDetails, reason string) error
+	NotifyRejected(ctx context.Context, post *domain.PostWithDetails, reason string) error
+	NotifyScheduled(ctx context.Context, post *domain.PostWithDetails, publishAt time.Time) error
 }
 
 type JobService struct {
-	cfg         *config.Config
-	jobRepo     *repository.JobRepository
-	companyRepo *repository.CompanyRepository
-	userRepo    *repository.UserRepository
-	publisher   Publisher
-	notifier    AdminNotifier
+	cfg          *config.Config
+	jobRepo      *repository.JobRepository
+	companyRepo  *repository.CompanyRepository
+	userRepo     *repository.UserRepository
+	revisionRepo *repository.RevisionRepository
+	eventRepo    *repository.PostEventRepository
+	outboxRepo   *repository.PublishOutboxRepository
+	paymentRepo  *repository.PaymentRepository
+	promoRepo    *repository.PromoCodeRepository
+	publisher    Publisher
+	notifier     AdminNotifier
 }
 
 func NewJobService(
@@ -39,20 +57,43 @@ func NewJobService(
 	jobRepo *repository.JobRepository,
 	companyRepo *repository.CompanyRepository,
 	userRepo *repository.UserRepository,
+	revisionRepo *repository.RevisionRepository,
+	eventRepo *repository.PostEventRepository,
+	outboxRepo *repository.PublishOutboxRepository,
+	paymentRepo *repository.PaymentRepository,
+	promoRepo *repository.PromoCodeRepository,
 	publisher Publisher,
 	notifier AdminNotifier,
 ) *JobService {
 	return &JobService{
-		cfg:         cfg,
-		jobRepo:     jobRepo,
-		companyRepo: companyRepo,
-		userRepo:    userRepo,
-		publisher:   publisher,
-		notifier:    notifier,
+		cfg:          cfg,
+		jobRepo:      jobRepo,
+		companyRepo:  companyRepo,
+		userRepo:     userRepo,
+		revisionRepo: revisionRepo,
+		eventRepo:    eventRepo,
+		outboxRepo:   outboxRepo,
+		paymentRepo:  paymentRepo,
+		promoRepo:    promoRepo,
+		publisher:    publisher,
+		notifier:     notifier,
 	}
 }
 
 func (s *JobService) CreateJob(ctx context.Context, telegramID int64, username string, req *domain.CreateJobRequest) (*domain.Job, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Check the promo code before anything is created
+	var promo *domain.PromoCode
+	if req.PromoCode != "" {
+		var err error
+		if promo, err = validatePromoCode(ctx, s.promoRepo, req.PromoCode); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get or create user
 	user, err := s.userRepo.GetOrCreate(ctx, telegramID, username)
 	if err != nil {
@@ -89,12 +130,25 @@ func (s *JobService) CreateJob(ctx context.Context, telegramID int64, username s
 		return nil, err
 	}
 
+	// The code may have run out of uses since it was checked; the post stays without it
+	promoCode := ""
+	if promo != nil {
+		redeemed, err := s.promoRepo.Redeem(ctx, promo.ID, job.ID)
+		if err != nil {
+			return nil, err
+		}
+		if redeemed {
+			promoCode = promo.Code
+		}
+	}
+
 	if s.notifier != nil {
 		jobWithCompany := &domain.PostWithDetails{
 			Post:             *job,
 			CompanyName:      company.Name,
 			CompanyContact:   company.Contact,
 			AuthorTelegramID: telegramID,
+			PromoCode:        promoCode,
 		}
 		_ = s.notifier.NotifyNewJob(ctx, jobWithCompany)
 	}
@@ -103,6 +157,10 @@ func (s *JobService) CreateJob(ctx context.Context, telegramID int64, username s
 }
 
 func (s *JobService) CreateResume(ctx context.Context, telegramID int64, username string, req *domain.CreateResumeRequest) (*domain.Post, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Get or create user
 	user, err := s.userRepo.GetOrCreate(ctx, telegramID, username)
 	if err != nil {
@@ -140,17 +198,34 @@ func (s *JobService) CreateResume(ctx context.Context, telegramID int64, usernam
 	return resume, nil
 }
 
+// isAdmin reports whether the verified caller may moderate posts
+func (s *JobService) isAdmin(principal *domain.Principal) bool {
+	return principal != nil && s.cfg.IsAdmin(principal.TelegramID)
+}
+
 func (s *JobService) GetPendingJobs(ctx context.Context) ([]domain.JobWithCompany, error) {
 	return s.jobRepo.GetByStatus(ctx, domain.JobStatusPending)
 }
 
+func (s *JobService) GetJobsByStatus(ctx context.Context, status domain.JobStatus, principal *domain.Principal) ([]domain.JobWithCompany, error) {
+	if !s.isAdmin(principal) {
+		return nil, ErrForbidden
+	}
+	return s.jobRepo.GetByStatus(ctx, status)
+}
+
 func (s *JobService) GetJobWithCompany(ctx context.Context, id uuid.UUID) (*domain.JobWithCompany, error) {
-	return s.jobRepo.GetWithCompany(ctx, id)
+	post, err := s.jobRepo.GetWithCompany(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return post, err
 }
 
-func (s *JobService) ApproveJob(ctx context.Context, jobID uuid.UUID, adminTelegramID int64) error {
+// ApproveJob approves a pending post with the given placement tier and queues it for publishing
+func (s *JobService) ApproveJob(ctx context.Context, jobID uuid.UUID, principal *domain.Principal, tier domain.PlacementTier) error {
 	// Check admin permission
-	if !s.cfg.IsAdmin(adminTelegramID) {
+	if !s.isAdmin(principal) {
 		return ErrForbidden
 	}
 
@@ -159,54 +234,115 @@ func (s *JobService) ApproveJob(ctx context.Context, jobID uuid.UUID, adminTeleg
 		return ErrNotFound
 	}
 
-	if job.Status != domain.JobStatusPending {
-		return ErrInvalidTransition
+	// A concurrent approve fails here, so the post is queued (and published) once
+	change, err := domain.Transition(job, domain.JobStatusApproved, domain.ActorAdmin)
+	if err != nil {
+		return err
 	}
-
-	if err := s.jobRepo.UpdateStatus(ctx, jobID, domain.JobStatusApproved); err != nil {
+	creditRequired, err := s.checkPaid(ctx, jobID, tier)
+	if err != nil {
 		return err
 	}
 
-	jobWithCompany, err := s.jobRepo.GetWithCompany(ctx, jobID)
+	// A standard post uses a credit of the author's prepaid package, if any.
+	// The publish worker sends it to the channel and marks it published.
+	return s.approve(ctx, jobID, change, tier, nil, principal, creditRequired)
+}
+
+// ScheduleJob approves a pending post and queues it for publishing at publishAt.
+// The author is told when the post goes live; one due already is announced
+// by the publish worker instead.
+func (s *JobService) ScheduleJob(ctx context.Context, jobID uuid.UUID, principal *domain.Principal, tier domain.PlacementTier, publishAt time.Time) error {
+	if !s.isAdmin(principal) {
+		return ErrForbidden
+	}
+
+	post, err := s.jobRepo.GetWithCompany(ctx, jobID)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	change, err := domain.Transition(&post.Post, domain.JobStatusApproved, domain.ActorAdmin)
+	if err != nil {
+		return err
+	}
+	creditRequired, err := s.checkPaid(ctx, jobID, tier)
 	if err != nil {
 		return err
 	}
 
-	var channelMessageID int
-	if s.publisher != nil {
-		channelMessageID, err = s.publisher.Publish(ctx, jobWithCompany)
-		if err != nil {
-			return err
-		}
+	publishAt = publishAt.UTC()
+	if err := s.approve(ctx, jobID, change, tier, &publishAt, principal, creditRequired); err != nil {
+		return err
 	}
 
-	// Set published status with channel message ID
-	return s.jobRepo.SetPublished(ctx, jobID, channelMessageID)
+	if s.notifier != nil && publishAt.After(time.Now()) {
+		_ = s.notifier.NotifyScheduled(ctx, post, publishAt)
+	}
+	return nil
 }
 
-func (s *JobService) RejectJob(ctx context.Context, jobID uuid.UUID, adminTelegramID int64, reason string) error {
+func (s *JobService) approve(ctx context.Context, jobID uuid.UUID, change domain.StatusChange, tier domain.PlacementTier, publishAt *time.Time, principal *domain.Principal, creditRequired bool) error {
+	err := s.jobRepo.Approve(ctx, jobID, change, tier, publishAt, &principal.TelegramID, creditRequired)
+	if errors.Is(err, domain.ErrNoPackageCredit) {
+		return ErrPaymentRequired
+	}
+	return err
+}
+
+// checkPaid blocks approval until a paid payment for the tier is linked to the
+// post, if the deployment requires it (REQUIRE_PAYMENT). Otherwise a standard
+// post must be covered by a credit of the author's prepaid package: it reports
+// that the credit is required, and jobRepo.Approve takes it in the approving
+// transaction, so two posts can't both be approved on the last credit.
+func (s *JobService) checkPaid(ctx context.Context, jobID uuid.UUID, tier domain.PlacementTier) (bool, error) {
+	if !s.cfg.RequirePayment {
+		return false, nil
+	}
+	paid, err := s.paymentRepo.HasPaidForPost(ctx, jobID, tier)
+	if err != nil {
+		return false, err
+	}
+	if paid {
+		return false, nil
+	}
+	if tier == domain.PlacementStandard {
+		return true, nil
+	}
+	return false, ErrPaymentRequired
+}
+
+// RejectJob rejects a pending post and sends the author the reason
+func (s *JobService) RejectJob(ctx context.Context, jobID uuid.UUID, principal *domain.Principal, reason string) error {
 	// Check admin permission
-	if !s.cfg.IsAdmin(adminTelegramID) {
+	if !s.isAdmin(principal) {
 		return ErrForbidden
 	}
 
 	// Get job
-	job, err := s.jobRepo.GetByID(ctx, jobID)
+	post, err := s.jobRepo.GetWithCompany(ctx, jobID)
 	if err != nil {
 		return ErrNotFound
 	}
 
-	// Validate transition: only pending -> rejected allowed
-	if job.Status != domain.JobStatusPending {
-		return ErrInvalidTransition
+	change, err := domain.Transition(&post.Post, domain.JobStatusRejected, domain.ActorAdmin)
+	if err != nil {
+		return err
+	}
+
+	if err := s.jobRepo.Reject(ctx, jobID, change, reason, &principal.TelegramID); err != nil {
+		return err
 	}
 
-	return s.jobRepo.UpdateStatus(ctx, jobID, domain.JobStatusRejected)
+	if s.notifier != nil {
+		_ = s.notifier.NotifyRejected(ctx, post, reason)
+	}
+	return nil
 }
 
-func (s *JobService) ArchiveJob(ctx context.Context, jobID uuid.UUID, adminTelegramID int64) error {
+func (s *JobService) ArchiveJob(ctx context.Context, jobID uuid.UUID, principal *domain.Principal) error {
 	// Check admin permission
-	if !s.cfg.IsAdmin(adminTelegramID) {
+	if !s.isAdmin(principal) {
 		return ErrForbidden
 	}
 
@@ -216,18 +352,54 @@ func (s *JobService) ArchiveJob(ctx context.Context, jobID uuid.UUID, adminTeleg
 		return ErrNotFound
 	}
 
-	// Can only archive published jobs
-	if job.Status != domain.JobStatusPublished {
-		return ErrInvalidTransition
+	// Admins archive published posts only; pending ones are rejected instead
+	change, err := domain.Transition(job, domain.JobStatusArchived, domain.ActorAdmin)
+	if err != nil {
+		return err
+	}
+
+	// Archive in DB first so a concurrent delete doesn't run twice
+	if err := s.jobRepo.Archive(ctx, jobID, change, "Deleted by admin", &principal.TelegramID); err != nil {
+		return err
 	}
 
 	// Delete from channel if we have the message ID
 	if s.publisher != nil && job.ChannelMessageID != nil {
 		_ = s.publisher.Delete(ctx, *job.ChannelMessageID)
 	}
+	return nil
+}
 
-	// Archive in DB
-	return s.jobRepo.Archive(ctx, jobID)
+// CloseJob lets the author withdraw their own post (e.g. the position is filled).
+// A published post is marked as closed in the channel (or deleted if that fails).
+func (s *JobService) CloseJob(ctx context.Context, principal *domain.Principal, jobID uuid.UUID, reason string) (*domain.PostWithDetails, error) {
+	post, err := s.getOwnPost(ctx, principal, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasPublished := post.Status == domain.JobStatusPublished
+	change, err := domain.Transition(&post.Post, domain.JobStatusArchived, domain.ActorAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.Archive(ctx, jobID, change, reason, &principal.TelegramID); err != nil {
+		return nil, err
+	}
+	post.StatusReason = reason
+
+	if wasPublished && s.publisher != nil && post.ChannelMessageID != nil {
+		if err := s.publisher.MarkClosed(ctx, *post.ChannelMessageID, post); err != nil {
+			_ = s.publisher.Delete(ctx, *post.ChannelMessageID)
+		}
+	}
+
+	if s.notifier != nil {
+		_ = s.notifier.NotifyPostClosed(ctx, post)
+	}
+
+	return post, nil
 }
 
 func (s *JobService) GetJob(ctx context.Context, jobID uuid.UUID) (*domain.Job, error) {
@@ -238,6 +410,31 @@ func (s *JobService) GetUserJobs(ctx context.Context, telegramID int64) ([]domai
 	return s.jobRepo.GetByUserTelegramID(ctx, telegramID)
 }
 
+// maxSearchQueryRunes caps free-text search queries
+const maxSearchQueryRunes = 200
+
+// Search returns a page of published posts matching the filter and the total number of matches
+func (s *JobService) Search(ctx context.Context, filter domain.SearchFilter, limit, offset int) ([]domain.PostWithDetails, int, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if runes := []rune(filter.Query); len(runes) > maxSearchQueryRunes {
+		filter.Query = string(runes[:maxSearchQueryRunes])
+	}
+	return s.jobRepo.Search(ctx, filter, limit, offset)
+}
+
 func (s *JobService) GetStats(ctx context.Context) (*domain.Stats, error) {
 	return s.jobRepo.GetStats(ctx)
 }
+
+// GetHistory returns the status transitions of a post for admins
+func (s *JobService) GetHistory(ctx context.Context, jobID uuid.UUID, principal *domain.Principal) ([]domain.PostEvent, error) {
+	if !s.isAdmin(principal) {
+		return nil, ErrForbidden
+	}
+
+	if _, err := s.jobRepo.GetByID(ctx, jobID); err != nil {
+		return nil, ErrNotFound
+	}
+
+	return s.eventRepo.ListByPost(ctx, jobID)
+}
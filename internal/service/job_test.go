@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"telegram-job/internal/domain"
+)
+
+// authorNotifier records the author notifications JobService sends
+type authorNotifier struct {
+	AdminNotifier
+	rejected  []string
+	scheduled []time.Time
+}
+
+func (n *authorNotifier) NotifyNewJob(ctx context.Context, post *domain.PostWithDetails) error {
+	return nil
+}
+
+func (n *authorNotifier) NotifyRejected(ctx context.Context, post *domain.PostWithDetails, reason string) error {
+	n.rejected = append(n.rejected, reason)
+	return nil
+}
+
+func (n *authorNotifier) NotifyScheduled(ctx context.Context, post *domain.PostWithDetails, publishAt time.Time) error {
+	n.scheduled = append(n.scheduled, publishAt)
+	return nil
+}
+
+func TestModerationNotifiesAuthor(t *testing.T) {
+	pt := newPublishTest(t)
+	notifier := &authorNotifier{}
+	pt.service.notifier = notifier
+	ctx := context.Background()
+
+	rejected := pt.submit(t)
+	if err := pt.service.RejectJob(ctx, rejected, pt.admin, "Invalid description"); err != nil {
+		t.Fatalf("RejectJob: %v", err)
+	}
+	if len(notifier.rejected) != 1 || notifier.rejected[0] != "Invalid description" {
+		t.Errorf("rejection notices = %q, want the reason once", notifier.rejected)
+	}
+
+	publishAt := time.Now().Add(time.Hour)
+	if err := pt.service.ScheduleJob(ctx, pt.submit(t), pt.admin, domain.PlacementStandard, publishAt); err != nil {
+		t.Fatalf("ScheduleJob: %v", err)
+	}
+	if len(notifier.scheduled) != 1 || !notifier.scheduled[0].Equal(publishAt.UTC()) {
+		t.Errorf("schedule notices = %v, want %v", notifier.scheduled, publishAt.UTC())
+	}
+
+	// A post due already is announced when it is published
+	if err := pt.service.ScheduleJob(ctx, pt.submit(t), pt.admin, domain.PlacementStandard, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("ScheduleJob: %v", err)
+	}
+	if len(notifier.scheduled) != 1 {
+		t.Errorf("%d schedule notices, want none for a post due already", len(notifier.scheduled)-1)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+var ErrInvalidPackage = errors.New("invalid package")
+
+// PackageService manages prepaid post packages. Credits are used
+// automatically when a standard post of the owner is approved.
+type PackageService struct {
+	cfg         *config.Config
+	packageRepo *repository.PackageRepository
+	userRepo    *repository.UserRepository
+}
+
+func NewPackageService(cfg *config.Config, packageRepo *repository.PackageRepository, userRepo *repository.UserRepository) *PackageService {
+	return &PackageService{
+		cfg:         cfg,
+		packageRepo: packageRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Grant gives a user a package of credits, valid until expiresAt (nil = no expiry)
+func (s *PackageService) Grant(ctx context.Context, adminTelegramID int64, telegramID int64, credits int, expiresAt *time.Time) (*domain.Package, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return nil, ErrForbidden
+	}
+	if credits <= 0 {
+		return nil, ErrInvalidPackage
+	}
+
+	user, err := s.userRepo.GetOrCreate(ctx, telegramID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &domain.Package{
+		UserID:       user.ID,
+		TotalCredits: credits,
+		ExpiresAt:    expiresAt,
+		GrantedBy:    &adminTelegramID,
+	}
+	if err := s.packageRepo.Create(ctx, pkg); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// Adjust adds (or removes, with a negative delta) credits and optionally moves the expiry
+func (s *PackageService) Adjust(ctx context.Context, adminTelegramID int64, packageID uuid.UUID, delta int, expiresAt *time.Time) (*domain.Package, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return nil, ErrForbidden
+	}
+
+	if _, err := s.packageRepo.GetByID(ctx, packageID); err != nil {
+		return nil, ErrNotFound
+	}
+
+	ok, err := s.packageRepo.Adjust(ctx, packageID, delta, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Can't take away credits that were already used
+		return nil, ErrInvalidPackage
+	}
+	return s.packageRepo.GetByID(ctx, packageID)
+}
+
+// GetBalance returns the packages of a user, newest first
+func (s *PackageService) GetBalance(ctx context.Context, telegramID int64) ([]domain.Package, error) {
+	user, err := s.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return nil, nil
+	}
+	return s.packageRepo.ListByUser(ctx, user.ID)
+}
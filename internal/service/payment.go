@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+var (
+	ErrPaymentRequired = errors.New("paid payment required")
+	ErrInvalidPayment  = errors.New("invalid payment")
+)
+
+// PaymentService is the admin-facing payments ledger
+type PaymentService struct {
+	cfg         *config.Config
+	paymentRepo *repository.PaymentRepository
+	jobRepo     *repository.JobRepository
+	promoRepo   *repository.PromoCodeRepository
+}
+
+func NewPaymentService(cfg *config.Config, paymentRepo *repository.PaymentRepository, jobRepo *repository.JobRepository, promoRepo *repository.PromoCodeRepository) *PaymentService {
+	return &PaymentService{
+		cfg:         cfg,
+		paymentRepo: paymentRepo,
+		jobRepo:     jobRepo,
+		promoRepo:   promoRepo,
+	}
+}
+
+// RecordPayment adds a pending payment against a post or a company.
+// A payment for a post is also linked to the post's company, and the
+// post's promo code (if any) is taken off the amount.
+func (s *PaymentService) RecordPayment(ctx context.Context, adminTelegramID int64, req *domain.CreatePaymentRequest) (*domain.Payment, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return nil, ErrForbidden
+	}
+
+	if req.Amount <= 0 || strings.TrimSpace(req.Currency) == "" || (req.PostID == nil && req.CompanyID == nil) {
+		return nil, ErrInvalidPayment
+	}
+	if req.Type == "" {
+		req.Type = domain.PaymentTypeSingle
+	}
+	if req.Type != domain.PaymentTypeSingle && req.Type != domain.PaymentTypePackage && req.Type != domain.PaymentTypeSubscription {
+		return nil, ErrInvalidPayment
+	}
+	if req.Tier != nil && *req.Tier != domain.PlacementStandard && *req.Tier != domain.PlacementFeatured {
+		return nil, ErrInvalidPayment
+	}
+
+	payment := &domain.Payment{
+		CompanyID:  req.CompanyID,
+		PostID:     req.PostID,
+		Amount:     req.Amount,
+		Currency:   strings.ToUpper(strings.TrimSpace(req.Currency)),
+		Method:     strings.TrimSpace(req.Method),
+		Type:       req.Type,
+		Tier:       req.Tier,
+		RecordedBy: &adminTelegramID,
+	}
+
+	if req.PostID != nil {
+		post, err := s.jobRepo.GetByID(ctx, *req.PostID)
+		if err != nil {
+			return nil, ErrNotFound
+		}
+		if payment.CompanyID == nil {
+			payment.CompanyID = post.CompanyID
+		}
+
+		tier := domain.PlacementStandard
+		if req.Tier != nil {
+			tier = *req.Tier
+		}
+		payment.Amount, payment.Discount, payment.PromoCodeID = s.applyPromo(ctx, *req.PostID, tier, req.Amount)
+	}
+
+	if err := s.paymentRepo.Create(ctx, payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// MarkPaid and MarkFailed settle a pending payment
+func (s *PaymentService) MarkPaid(ctx context.Context, adminTelegramID int64, paymentID uuid.UUID) (*domain.Payment, error) {
+	return s.settle(ctx, adminTelegramID, paymentID, domain.PaymentStatusPaid)
+}
+
+func (s *PaymentService) MarkFailed(ctx context.Context, adminTelegramID int64, paymentID uuid.UUID) (*domain.Payment, error) {
+	return s.settle(ctx, adminTelegramID, paymentID, domain.PaymentStatusFailed)
+}
+
+// Price returns the list price of a placement tier in minor units
+func (s *PaymentService) Price(tier domain.PlacementTier) int {
+	if tier == domain.PlacementFeatured {
+		return s.cfg.PriceFeatured
+	}
+	return s.cfg.PriceStandard
+}
+
+// PriceFor returns the invoice amount of a post: the tier price minus its promo code
+func (s *PaymentService) PriceFor(ctx context.Context, postID uuid.UUID, tier domain.PlacementTier) int {
+	amount, _, _ := s.applyPromo(ctx, postID, tier, s.Price(tier))
+	return amount
+}
+
+// applyPromo takes the post's promo code off amount if the code covers the tier.
+// A code stays valid for a post that redeemed it, unless an admin disabled it.
+func (s *PaymentService) applyPromo(ctx context.Context, postID uuid.UUID, tier domain.PlacementTier, amount int) (int, int, *uuid.UUID) {
+	promo, err := s.promoRepo.GetForPost(ctx, postID)
+	if err != nil || promo.DisabledAt != nil || !promo.AppliesTo(tier) {
+		return amount, 0, nil
+	}
+	discount := promo.Discount(amount)
+	return amount - discount, discount, &promo.ID
+}
+
+// CheckInvoice verifies that the author may still pay for a post with the
+// given tier and amount (used to answer pre_checkout_query)
+func (s *PaymentService) CheckInvoice(ctx context.Context, telegramID int64, postID uuid.UUID, tier domain.PlacementTier, amount int, currency string) error {
+	if s.cfg.PaymentProviderToken == "" {
+		return ErrInvalidPayment
+	}
+	if tier != domain.PlacementStandard && tier != domain.PlacementFeatured {
+		return ErrInvalidPayment
+	}
+	if amount != s.PriceFor(ctx, postID, tier) || !strings.EqualFold(currency, s.cfg.PaymentCurrency) {
+		return ErrInvalidPayment
+	}
+
+	post, err := s.jobRepo.GetWithCompany(ctx, postID)
+	if err != nil || post.AuthorTelegramID != telegramID {
+		return ErrNotFound
+	}
+	if post.Status != domain.JobStatusPending {
+		return ErrInvalidTransition
+	}
+
+	paid, err := s.paymentRepo.HasPaidForPost(ctx, postID, tier)
+	if err != nil {
+		return err
+	}
+	if paid {
+		return ErrInvalidTransition
+	}
+	return nil
+}
+
+// RecordTelegramPayment stores a successful Telegram payment as paid. A payment
+// with the same charge ID is recorded once; the second return value reports
+// whether this call created it.
+func (s *PaymentService) RecordTelegramPayment(ctx context.Context, postID uuid.UUID, tier domain.PlacementTier, amount int, currency, chargeID string) (*domain.Payment, bool, error) {
+	if existing, err := s.paymentRepo.GetByExternalID(ctx, chargeID); err == nil {
+		return existing, false, nil
+	}
+
+	post, err := s.jobRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, false, ErrNotFound
+	}
+
+	// The invoice already had the discount applied
+	_, discount, promoID := s.applyPromo(ctx, postID, tier, s.Price(tier))
+
+	now := time.Now().UTC()
+	payment := &domain.Payment{
+		CompanyID:   post.CompanyID,
+		PostID:      &postID,
+		Amount:      amount,
+		Currency:    strings.ToUpper(currency),
+		Method:      "telegram",
+		Type:        domain.PaymentTypeSingle,
+		Tier:        &tier,
+		Status:      domain.PaymentStatusPaid,
+		ExternalID:  chargeID,
+		PromoCodeID: promoID,
+		Discount:    discount,
+		PaidAt:      &now,
+	}
+	if err := s.paymentRepo.Create(ctx, payment); err != nil {
+		return nil, false, err
+	}
+	return payment, true, nil
+}
+
+func (s *PaymentService) ListPostPayments(ctx context.Context, adminTelegramID int64, postID uuid.UUID) ([]domain.Payment, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return nil, ErrForbidden
+	}
+	return s.paymentRepo.ListByPost(ctx, postID)
+}
+
+func (s *PaymentService) settle(ctx context.Context, adminTelegramID int64, paymentID uuid.UUID, status domain.PaymentStatus) (*domain.Payment, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return nil, ErrForbidden
+	}
+
+	ok, err := s.paymentRepo.SetStatus(ctx, paymentID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if !ok {
+		return payment, ErrInvalidTransition
+	}
+	return payment, nil
+}
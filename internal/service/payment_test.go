@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+)
+
+// pay records a paid payment for a post
+func (pt *publishTest) pay(t *testing.T, postID uuid.UUID, tier *domain.PlacementTier) {
+	t.Helper()
+
+	_, err := pt.db.Pool.Exec(context.Background(), `
+		INSERT INTO payments (post_id, amount, currency, type, tier, status, paid_at)
+		VALUES ($1, 5000, 'USD', 'single', $2, 'paid', now())
+	`, postID, tier)
+	if err != nil {
+		t.Fatalf("recording payment: %v", err)
+	}
+}
+
+func TestApproveRequiresPaymentForTier(t *testing.T) {
+	pt := newPublishTest(t)
+	pt.service.cfg.RequirePayment = true
+	ctx := context.Background()
+
+	standard, featured := domain.PlacementStandard, domain.PlacementFeatured
+
+	postID := pt.submit(t)
+	pt.pay(t, postID, &standard)
+	if err := pt.service.ApproveJob(ctx, postID, pt.admin, featured); err != ErrPaymentRequired {
+		t.Errorf("featured approval paid as standard = %v, want ErrPaymentRequired", err)
+	}
+	if err := pt.service.ApproveJob(ctx, postID, pt.admin, standard); err != nil {
+		t.Errorf("standard approval paid as standard = %v", err)
+	}
+
+	postID = pt.submit(t)
+	pt.pay(t, postID, &featured)
+	if err := pt.service.ApproveJob(ctx, postID, pt.admin, standard); err != nil {
+		t.Errorf("standard approval paid as featured = %v", err)
+	}
+
+	postID = pt.submit(t)
+	pt.pay(t, postID, nil)
+	if err := pt.service.ApproveJob(ctx, postID, pt.admin, featured); err != nil {
+		t.Errorf("featured approval paid without a tier = %v", err)
+	}
+}
+
+func TestLastPackageCreditApprovesOnePost(t *testing.T) {
+	pt := newPublishTest(t)
+	pt.service.cfg.RequirePayment = true
+	ctx := context.Background()
+
+	first, second := pt.submit(t), pt.submit(t)
+	_, err := pt.db.Pool.Exec(ctx, `
+		INSERT INTO packages (user_id, total_credits, used_credits)
+		SELECT user_id, 1, 0 FROM posts WHERE id = $1
+	`, first)
+	if err != nil {
+		t.Fatalf("granting package: %v", err)
+	}
+
+	if err := pt.service.ApproveJob(ctx, first, pt.admin, domain.PlacementStandard); err != nil {
+		t.Fatalf("approving on the last credit: %v", err)
+	}
+	if err := pt.service.ApproveJob(ctx, second, pt.admin, domain.PlacementStandard); err != ErrPaymentRequired {
+		t.Errorf("approving with no credit left = %v, want ErrPaymentRequired", err)
+	}
+
+	post, err := pt.service.GetJob(ctx, second)
+	if err != nil {
+		t.Fatalf("loading post: %v", err)
+	}
+	if post.Status != domain.JobStatusPending {
+		t.Errorf("status = %s, want pending after the failed approval", post.Status)
+	}
+	var used int
+	if err := pt.db.Pool.QueryRow(ctx, `SELECT used_credits FROM packages`).Scan(&used); err != nil {
+		t.Fatalf("reading package: %v", err)
+	}
+	if used != 1 {
+		t.Errorf("used credits = %d, want 1", used)
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"telegram-job/internal/domain"
+)
+
+func TestFeaturedPostIsPinnedAndUnpinned(t *testing.T) {
+	pt := newPublishTest(t)
+	ctx := context.Background()
+	postID := pt.approve(t, domain.PlacementFeatured)
+
+	if _, err := pt.service.ProcessPublishQueue(ctx); err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+
+	post, err := pt.service.GetJob(ctx, postID)
+	if err != nil {
+		t.Fatalf("loading post: %v", err)
+	}
+	if post.ChannelMessageID == nil {
+		t.Fatal("featured post wasn't published")
+	}
+	messageID := strconv.Itoa(*post.ChannelMessageID)
+
+	pins := pt.fake.Calls("pinChatMessage")
+	if len(pins) != 1 {
+		t.Fatalf("pinChatMessage called %d times, want 1", len(pins))
+	}
+	if got := pins[0].Params.Get("message_id"); got != messageID {
+		t.Errorf("pinned message %s, want %s", got, messageID)
+	}
+	if post.PinUntil == nil || post.PinUntil.Before(time.Now().Add(47*time.Hour)) {
+		t.Errorf("pin until %v, want FeaturedPinHours from now", post.PinUntil)
+	}
+
+	// Nothing to unpin while the pin window is open
+	if unpinned, err := pt.service.UnpinExpired(ctx); err != nil || len(unpinned) != 0 {
+		t.Fatalf("UnpinExpired = %d posts, %v; want none", len(unpinned), err)
+	}
+
+	_, err = pt.db.Pool.Exec(ctx, `UPDATE posts SET pin_until = now() - interval '1 minute' WHERE id = $1`, postID)
+	if err != nil {
+		t.Fatalf("ending pin window: %v", err)
+	}
+	unpinned, err := pt.service.UnpinExpired(ctx)
+	if err != nil {
+		t.Fatalf("UnpinExpired: %v", err)
+	}
+	if len(unpinned) != 1 {
+		t.Fatalf("%d posts unpinned, want 1", len(unpinned))
+	}
+
+	unpins := pt.fake.Calls("unpinChatMessage")
+	if len(unpins) != 1 {
+		t.Fatalf("unpinChatMessage called %d times, want 1", len(unpins))
+	}
+	if got := unpins[0].Params.Get("message_id"); got != messageID {
+		t.Errorf("unpinned message %s, want %s", got, messageID)
+	}
+
+	// An unpinned post isn't unpinned again
+	if _, err := pt.service.UnpinExpired(ctx); err != nil {
+		t.Fatalf("UnpinExpired: %v", err)
+	}
+	if n := len(pt.fake.Calls("unpinChatMessage")); n != 1 {
+		t.Errorf("unpinChatMessage called %d times, want 1", n)
+	}
+}
+
+func TestStandardPostIsNotPinned(t *testing.T) {
+	pt := newPublishTest(t)
+	pt.approve(t, domain.PlacementStandard)
+
+	if _, err := pt.service.ProcessPublishQueue(context.Background()); err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+	if n := len(pt.fake.Calls("sendMessage")); n != 1 {
+		t.Fatalf("sendMessage called %d times, want 1", n)
+	}
+	if n := len(pt.fake.Calls("pinChatMessage")); n != 0 {
+		t.Errorf("standard post pinned %d times", n)
+	}
+}
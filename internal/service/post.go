@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+const (
+	defaultPostPageSize = 20
+	maxPostPageSize     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PostService serves published posts to the public API
+type PostService struct {
+	cfg     *config.Config
+	jobRepo *repository.JobRepository
+}
+
+func NewPostService(cfg *config.Config, jobRepo *repository.JobRepository) *PostService {
+	return &PostService{
+		cfg:     cfg,
+		jobRepo: jobRepo,
+	}
+}
+
+// ListPosts returns a page of published posts and the cursor of the next
+// page, or nil if this is the last one
+func (s *PostService) ListPosts(ctx context.Context, filter domain.PostListFilter) ([]domain.PublicPost, *domain.PostCursor, error) {
+	if filter.Sort == "" {
+		filter.Sort = domain.SortNewest
+	}
+	if filter.After != nil && filter.After.Sort != filter.Sort {
+		return nil, nil, ErrInvalidCursor
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPostPageSize
+	}
+	if filter.Limit > maxPostPageSize {
+		filter.Limit = maxPostPageSize
+	}
+
+	// One extra row tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	posts, err := s.jobRepo.ListPublished(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *domain.PostCursor
+	if len(posts) > limit {
+		posts = posts[:limit]
+		last := posts[limit-1]
+		next = &domain.PostCursor{Sort: filter.Sort, PublishedAt: *last.PublishedAt, ID: last.ID}
+		if filter.Sort == domain.SortSalary {
+			next.Salary = salarySortKey(&last)
+		}
+	}
+
+	result := make([]domain.PublicPost, 0, len(posts))
+	for i := range posts {
+		result = append(result, s.publicPost(&posts[i]))
+	}
+	return result, next, nil
+}
+
+// GetPost returns a published post; anything else is ErrNotFound
+func (s *PostService) GetPost(ctx context.Context, id uuid.UUID) (*domain.PublicPost, error) {
+	post, err := s.jobRepo.GetWithCompany(ctx, id)
+	if err != nil || post.Status != domain.JobStatusPublished || post.ChannelMessageID == nil {
+		return nil, ErrNotFound
+	}
+	public := s.publicPost(post)
+	return &public, nil
+}
+
+func (s *PostService) publicPost(post *domain.PostWithDetails) domain.PublicPost {
+	public := domain.PublicPost{
+		ID:              post.ID,
+		PostType:        post.PostType,
+		Title:           post.Title,
+		Level:           post.Level,
+		Type:            post.Type,
+		Category:        post.Category,
+		SalaryFrom:      post.SalaryFrom,
+		SalaryTo:        post.SalaryTo,
+		Description:     post.Description,
+		ApplyLink:       post.ApplyLink,
+		ExperienceYears: post.ExperienceYears,
+		Employment:      post.Employment,
+		About:           post.About,
+		ResumeLink:      post.ResumeLink,
+		Contact:         post.Contact,
+		Language:        post.Language,
+		Featured:        post.Tier == domain.PlacementFeatured,
+		PublishedAt:     post.PublishedAt,
+		URL:             s.cfg.ChannelPostLink(*post.ChannelMessageID),
+	}
+	if post.CompanyName != "" {
+		public.Company = &domain.PublicCompany{Name: post.CompanyName}
+	}
+	return public
+}
+
+// salarySortKey mirrors the salary order of JobRepository.ListPublished
+func salarySortKey(post *domain.PostWithDetails) int {
+	if post.SalaryTo != nil {
+		return *post.SalaryTo
+	}
+	if post.SalaryFrom != nil {
+		return *post.SalaryFrom
+	}
+	return 0
+}
+
+// EncodeCursor turns a cursor into an opaque token for the next_cursor field
+func EncodeCursor(cursor *domain.PostCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(token string) (*domain.PostCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor domain.PostCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+var (
+	ErrInvalidPromoCode = errors.New("invalid promo code")
+	ErrPromoCodeExists  = errors.New("promo code already exists")
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// PromoService manages discount codes. Recruiters enter a code before
+// submitting a vacancy; the discount is applied when a payment is recorded.
+type PromoService struct {
+	cfg       *config.Config
+	promoRepo *repository.PromoCodeRepository
+}
+
+func NewPromoService(cfg *config.Config, promoRepo *repository.PromoCodeRepository) *PromoService {
+	return &PromoService{
+		cfg:       cfg,
+		promoRepo: promoRepo,
+	}
+}
+
+// NormalizePromoCode trims and upper-cases a code as entered by a user
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (s *PromoService) Create(ctx context.Context, adminTelegramID int64, promo *domain.PromoCode) error {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return ErrForbidden
+	}
+
+	promo.Code = NormalizePromoCode(promo.Code)
+	if !promoCodePattern.MatchString(promo.Code) || promo.Value <= 0 {
+		return ErrInvalidPromoCode
+	}
+	if promo.Kind != domain.DiscountPercent && promo.Kind != domain.DiscountFixed {
+		return ErrInvalidPromoCode
+	}
+	if promo.Kind == domain.DiscountPercent && promo.Value > 100 {
+		return ErrInvalidPromoCode
+	}
+	if promo.Tier != nil && *promo.Tier != domain.PlacementStandard && *promo.Tier != domain.PlacementFeatured {
+		return ErrInvalidPromoCode
+	}
+	if promo.MaxUses != nil && *promo.MaxUses <= 0 {
+		return ErrInvalidPromoCode
+	}
+
+	if _, err := s.promoRepo.GetByCode(ctx, promo.Code); err == nil {
+		return ErrPromoCodeExists
+	}
+
+	promo.CreatedBy = &adminTelegramID
+	return s.promoRepo.Create(ctx, promo)
+}
+
+func (s *PromoService) Disable(ctx context.Context, adminTelegramID int64, code string) error {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return ErrForbidden
+	}
+
+	ok, err := s.promoRepo.Disable(ctx, NormalizePromoCode(code))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *PromoService) List(ctx context.Context, adminTelegramID int64) ([]domain.PromoCode, error) {
+	if !s.cfg.IsAdmin(adminTelegramID) {
+		return nil, ErrForbidden
+	}
+	return s.promoRepo.List(ctx)
+}
+
+// Validate returns the promo code if it can be redeemed now
+func (s *PromoService) Validate(ctx context.Context, code string) (*domain.PromoCode, error) {
+	return validatePromoCode(ctx, s.promoRepo, code)
+}
+
+func validatePromoCode(ctx context.Context, promoRepo *repository.PromoCodeRepository, code string) (*domain.PromoCode, error) {
+	promo, err := promoRepo.GetByCode(ctx, NormalizePromoCode(code))
+	if err != nil || !promo.Usable(time.Now()) {
+		return nil, ErrInvalidPromoCode
+	}
+	return promo, nil
+}
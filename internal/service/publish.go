@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+)
+
+// ErrNoFailedPublish is returned when retrying a post that isn't waiting for an admin
+var ErrNoFailedPublish = errors.New("no failed publish task")
+
+const (
+	publishLease       = 2 * time.Minute  // How long a claimed task is hidden from other workers
+	maxPublishAttempts = 10               // After this many failures admins are asked to step in
+	maxPublishBackoff  = 30 * time.Minute // Upper bound of the retry delay
+)
+
+// ProcessPublishQueue publishes due posts from the outbox, at most
+// cfg.ChannelPostsPerMinute per minute, and returns the ones that went out.
+// Failed attempts are retried with exponential backoff, or after the delay
+// Telegram asks for when it rate limits the bot.
+func (s *JobService) ProcessPublishQueue(ctx context.Context) ([]domain.PostWithDetails, error) {
+	now := time.Now().UTC()
+
+	sent, err := s.jobRepo.CountPublishedSince(ctx, now.Add(-time.Minute))
+	if err != nil {
+		return nil, err
+	}
+
+	var published []domain.PostWithDetails
+	for budget := s.cfg.ChannelPostsPerMinute - sent; budget > 0; budget-- {
+		task, err := s.outboxRepo.ClaimNext(ctx, now, publishLease)
+		if err != nil {
+			return published, err
+		}
+		if task == nil {
+			break
+		}
+
+		post, err := s.jobRepo.GetWithCompany(ctx, task.PostID)
+		if err != nil {
+			return published, err
+		}
+		if post.Status != domain.JobStatusApproved {
+			// Already published or no longer approved: nothing left to do
+			if err := s.outboxRepo.Done(ctx, task.ID); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		err = s.publishApproved(ctx, post, task)
+		if err == nil {
+			if err := s.outboxRepo.Done(ctx, task.ID); err != nil {
+				log.Printf("Error removing publish task %s: %v", task.ID, err)
+			}
+			published = append(published, *post)
+			continue
+		}
+
+		s.failPublishTask(ctx, task, post, err)
+
+		// Telegram asked us to slow down: leave the rest of the queue for later
+		var retryErr *domain.RetryAfterError
+		if errors.As(err, &retryErr) {
+			break
+		}
+	}
+	return published, nil
+}
+
+// publishApproved sends an approved post to the channel and marks it published
+// once the message ID comes back. The ID is kept on the task first: if marking
+// the post fails, the retry reuses the message instead of posting it again.
+func (s *JobService) publishApproved(ctx context.Context, post *domain.PostWithDetails, task *domain.PublishTask) error {
+	change, err := domain.Transition(&post.Post, domain.JobStatusPublished, domain.ActorSystem)
+	if err != nil {
+		return err
+	}
+
+	var channelMessageID int
+	if task.ChannelMessageID != nil {
+		channelMessageID = *task.ChannelMessageID
+	} else if s.publisher != nil {
+		channelMessageID, err = s.publisher.Publish(ctx, post)
+		if err != nil {
+			post.Status = change.From
+			return err
+		}
+		if err := s.outboxRepo.SetMessageID(ctx, task.ID, channelMessageID); err != nil {
+			log.Printf("Error saving channel message of post %s: %v", post.ID, err)
+		} else {
+			task.ChannelMessageID = &channelMessageID
+		}
+	}
+
+	// Set published status with channel message ID
+	if err := s.jobRepo.SetPublished(ctx, post.ID, change, channelMessageID, task.ActorTelegramID); err != nil {
+		post.Status = change.From
+		return err
+	}
+
+	if post.Tier == domain.PlacementFeatured && s.publisher != nil {
+		s.pinPost(ctx, post, channelMessageID)
+	}
+	return nil
+}
+
+// pinPost pins a freshly published featured post for cfg.FeaturedPinHours.
+// A failed pin is only logged: the post itself is already out.
+func (s *JobService) pinPost(ctx context.Context, post *domain.PostWithDetails, channelMessageID int) {
+	if err := s.publisher.Pin(ctx, channelMessageID); err != nil {
+		log.Printf("Error pinning featured post %s: %v", post.ID, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	pinUntil := now.Add(time.Duration(s.cfg.FeaturedPinHours) * time.Hour)
+	if err := s.jobRepo.SetPinned(ctx, post.ID, now, pinUntil); err != nil {
+		log.Printf("Error saving pin of post %s: %v", post.ID, err)
+		return
+	}
+	post.PinnedAt = &now
+	post.PinUntil = &pinUntil
+}
+
+// UnpinExpired unpins featured posts whose pin window is over and returns them
+func (s *JobService) UnpinExpired(ctx context.Context) ([]domain.Post, error) {
+	now := time.Now().UTC()
+	posts, err := s.jobRepo.GetPinExpired(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var unpinned []domain.Post
+	for _, post := range posts {
+		ok, err := s.jobRepo.SetUnpinned(ctx, post.ID, now)
+		if err != nil {
+			return unpinned, err
+		}
+		if !ok {
+			continue
+		}
+
+		// Archived posts may already be gone from the channel, so errors are only logged
+		if s.publisher != nil && post.ChannelMessageID != nil {
+			if err := s.publisher.Unpin(ctx, *post.ChannelMessageID); err != nil {
+				log.Printf("Error unpinning post %s: %v", post.ID, err)
+			}
+		}
+		post.UnpinnedAt = &now
+		unpinned = append(unpinned, post)
+	}
+	return unpinned, nil
+}
+
+// RetryPublish puts an approved post whose publishing failed for good back in
+// the queue with a fresh set of attempts
+func (s *JobService) RetryPublish(ctx context.Context, jobID uuid.UUID, principal *domain.Principal) error {
+	if !s.isAdmin(principal) {
+		return ErrForbidden
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return ErrNotFound
+	}
+	if job.Status != domain.JobStatusApproved {
+		return ErrNoFailedPublish
+	}
+
+	ok, err := s.outboxRepo.Requeue(ctx, jobID, &principal.TelegramID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNoFailedPublish
+	}
+	return nil
+}
+
+func (s *JobService) failPublishTask(ctx context.Context, task *domain.PublishTask, post *domain.PostWithDetails, publishErr error) {
+	// Rate limiting isn't a failure: wait as long as Telegram asked, without
+	// using up an attempt
+	var retryErr *domain.RetryAfterError
+	if errors.As(publishErr, &retryErr) {
+		log.Printf("Publishing post %s rate limited, retrying in %s", post.ID, retryErr.RetryAfter)
+		if err := s.outboxRepo.Postpone(ctx, task.ID, time.Now().UTC().Add(retryErr.RetryAfter), publishErr.Error()); err != nil {
+			log.Printf("Error rescheduling publish task %s: %v", task.ID, err)
+		}
+		return
+	}
+
+	attempts := task.Attempts + 1
+	log.Printf("Error publishing post %s (attempt %d): %v", post.ID, attempts, publishErr)
+
+	if attempts >= maxPublishAttempts {
+		if err := s.outboxRepo.Fail(ctx, task.ID, publishErr.Error()); err != nil {
+			log.Printf("Error failing publish task %s: %v", task.ID, err)
+		}
+		if s.notifier != nil {
+			_ = s.notifier.NotifyPublishFailed(ctx, post, publishErr.Error())
+		}
+		return
+	}
+
+	if err := s.outboxRepo.Retry(ctx, task.ID, time.Now().UTC().Add(publishBackoff(attempts)), publishErr.Error()); err != nil {
+		log.Printf("Error rescheduling publish task %s: %v", task.ID, err)
+	}
+}
+
+// publishBackoff returns the delay before the next attempt: 10s doubled per
+// attempt up to maxPublishBackoff
+func publishBackoff(attempts int) time.Duration {
+	delay := 10 * time.Second
+	for i := 1; i < attempts && delay < maxPublishBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxPublishBackoff {
+		delay = maxPublishBackoff
+	}
+	return delay
+}
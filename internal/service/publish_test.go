@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/config"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/publisher"
+	"telegram-job/internal/repository"
+	"telegram-job/internal/testutil"
+)
+
+const testAdminID int64 = 1001
+
+// publishTest is a JobService publishing through a fake Bot API into a test database
+type publishTest struct {
+	service *JobService
+	fake    *testutil.FakeBotAPI
+	db      *repository.DB
+	admin   *domain.Principal
+}
+
+func newPublishTest(t *testing.T) *publishTest {
+	t.Helper()
+
+	db := testutil.OpenDB(t)
+	fake := testutil.NewFakeBotAPI(t)
+	cfg := &config.Config{
+		ChannelID:             -1001234567890,
+		AdminTelegramIDs:      map[int64]bool{testAdminID: true},
+		ChannelPostsPerMinute: 20,
+		FeaturedPinHours:      48,
+	}
+
+	s := NewJobService(cfg, repository.NewJobRepository(db), repository.NewCompanyRepository(db), repository.NewUserRepository(db),
+		repository.NewRevisionRepository(db), repository.NewPostEventRepository(db), repository.NewPublishOutboxRepository(db),
+		repository.NewPaymentRepository(db), repository.NewPromoCodeRepository(db),
+		publisher.NewChannelPublisher(fake.API(t), cfg), nil)
+
+	return &publishTest{
+		service: s,
+		fake:    fake,
+		db:      db,
+		admin:   &domain.Principal{Kind: domain.PrincipalBot, TelegramID: testAdminID},
+	}
+}
+
+// submit creates a pending vacancy of author 2002
+func (pt *publishTest) submit(t *testing.T) uuid.UUID {
+	t.Helper()
+
+	post, err := pt.service.CreateJob(context.Background(), 2002, "author", &domain.CreateJobRequest{
+		Company:     "Acme",
+		Contact:     "@acme_hr",
+		Title:       "Go developer",
+		Level:       domain.JobLevelSenior,
+		Type:        domain.JobTypeRemote,
+		Category:    domain.JobCategoryDev,
+		Description: "Backend services in Go",
+		ApplyLink:   "https://example.com/apply",
+		Language:    "en",
+	})
+	if err != nil {
+		t.Fatalf("creating vacancy: %v", err)
+	}
+	return post.ID
+}
+
+// approve submits a vacancy and approves it with tier, queueing it for publishing
+func (pt *publishTest) approve(t *testing.T, tier domain.PlacementTier) uuid.UUID {
+	t.Helper()
+
+	postID := pt.submit(t)
+	if err := pt.service.ApproveJob(context.Background(), postID, pt.admin, tier); err != nil {
+		t.Fatalf("approving vacancy: %v", err)
+	}
+	return postID
+}
+
+// task returns the attempts and next attempt time of a post's outbox task
+func (pt *publishTest) task(t *testing.T, postID uuid.UUID) (int, *time.Time) {
+	t.Helper()
+
+	var attempts int
+	var nextAttemptAt *time.Time
+	err := pt.db.Pool.QueryRow(context.Background(),
+		`SELECT attempts, next_attempt_at FROM publish_outbox WHERE post_id = $1`, postID,
+	).Scan(&attempts, &nextAttemptAt)
+	if err != nil {
+		t.Fatalf("reading publish task: %v", err)
+	}
+	return attempts, nextAttemptAt
+}
+
+// makeDue moves the post's outbox task to the past so the next run picks it up
+func (pt *publishTest) makeDue(t *testing.T, postID uuid.UUID) {
+	t.Helper()
+
+	_, err := pt.db.Pool.Exec(context.Background(),
+		`UPDATE publish_outbox SET next_attempt_at = now() - interval '1 minute' WHERE post_id = $1`, postID)
+	if err != nil {
+		t.Fatalf("rescheduling publish task: %v", err)
+	}
+}
+
+func TestPublishQueueRetryAfterIsNotAnAttempt(t *testing.T) {
+	pt := newPublishTest(t)
+	pt.fake.Handle("sendMessage", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 429, Description: "Too Many Requests: retry after 30", RetryAfter: 30}
+	})
+	postID := pt.approve(t, domain.PlacementStandard)
+
+	started := time.Now()
+	published, err := pt.service.ProcessPublishQueue(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+	if len(published) != 0 {
+		t.Fatalf("%d posts published while rate limited", len(published))
+	}
+
+	attempts, nextAttemptAt := pt.task(t, postID)
+	if attempts != 0 {
+		t.Errorf("attempts = %d after retry_after, want 0", attempts)
+	}
+	if nextAttemptAt == nil || nextAttemptAt.Before(started.Add(30*time.Second)) {
+		t.Errorf("next attempt at %v, want at least 30s from now", nextAttemptAt)
+	}
+
+	// Any other error does count as an attempt
+	pt.fake.Handle("sendMessage", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 400, Description: "Bad Request: chat not found"}
+	})
+	pt.makeDue(t, postID)
+	if _, err := pt.service.ProcessPublishQueue(context.Background()); err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+	if attempts, _ := pt.task(t, postID); attempts != 1 {
+		t.Errorf("attempts = %d after a failed send, want 1", attempts)
+	}
+
+	post, err := pt.service.GetJob(context.Background(), postID)
+	if err != nil {
+		t.Fatalf("loading post: %v", err)
+	}
+	if post.Status != domain.JobStatusApproved {
+		t.Errorf("status = %s, want approved until it is sent", post.Status)
+	}
+}
+
+func TestPublishQueueReusesSavedMessage(t *testing.T) {
+	pt := newPublishTest(t)
+	postID := pt.approve(t, domain.PlacementStandard)
+
+	// A previous run sent the message but failed to mark the post published
+	_, err := pt.db.Pool.Exec(context.Background(),
+		`UPDATE publish_outbox SET channel_message_id = 555 WHERE post_id = $1`, postID)
+	if err != nil {
+		t.Fatalf("saving message ID: %v", err)
+	}
+
+	published, err := pt.service.ProcessPublishQueue(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+	if len(published) != 1 {
+		t.Fatalf("%d posts published, want 1", len(published))
+	}
+	if n := len(pt.fake.Calls("sendMessage")); n != 0 {
+		t.Errorf("post sent %d more times, want it reused", n)
+	}
+
+	post, err := pt.service.GetJob(context.Background(), postID)
+	if err != nil {
+		t.Fatalf("loading post: %v", err)
+	}
+	if post.Status != domain.JobStatusPublished {
+		t.Errorf("status = %s, want published", post.Status)
+	}
+	if post.ChannelMessageID == nil || *post.ChannelMessageID != 555 {
+		t.Errorf("channel message ID = %v, want 555", post.ChannelMessageID)
+	}
+}
+
+func TestRetryPublishRequeuesFailedTask(t *testing.T) {
+	pt := newPublishTest(t)
+	pt.fake.Handle("sendMessage", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 400, Description: "Bad Request: chat not found"}
+	})
+	postID := pt.approve(t, domain.PlacementStandard)
+	ctx := context.Background()
+
+	if err := pt.service.RetryPublish(ctx, postID, pt.admin); err != ErrNoFailedPublish {
+		t.Fatalf("RetryPublish of a queued post = %v, want ErrNoFailedPublish", err)
+	}
+
+	// The last attempt fails and the task stops
+	_, err := pt.db.Pool.Exec(ctx, `UPDATE publish_outbox SET attempts = $1 WHERE post_id = $2`, maxPublishAttempts-1, postID)
+	if err != nil {
+		t.Fatalf("setting attempts: %v", err)
+	}
+	if _, err := pt.service.ProcessPublishQueue(ctx); err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+	if _, nextAttemptAt := pt.task(t, postID); nextAttemptAt != nil {
+		t.Fatalf("next attempt at %v after the last attempt, want none", nextAttemptAt)
+	}
+
+	author := &domain.Principal{Kind: domain.PrincipalBot, TelegramID: 2002}
+	if err := pt.service.RetryPublish(ctx, postID, author); err != ErrForbidden {
+		t.Errorf("RetryPublish by the author = %v, want ErrForbidden", err)
+	}
+	if err := pt.service.RetryPublish(ctx, postID, pt.admin); err != nil {
+		t.Fatalf("RetryPublish: %v", err)
+	}
+	attempts, nextAttemptAt := pt.task(t, postID)
+	if attempts != 0 || nextAttemptAt == nil {
+		t.Errorf("task after retry: attempts = %d, next attempt at %v, want 0 and due", attempts, nextAttemptAt)
+	}
+
+	pt.fake.Handle("sendMessage", func(params url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{Result: pt.fake.NewMessage(params.Get("chat_id"))}
+	})
+	published, err := pt.service.ProcessPublishQueue(ctx)
+	if err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+	if len(published) != 1 {
+		t.Errorf("%d posts published after retry, want 1", len(published))
+	}
+}
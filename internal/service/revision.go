@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+)
+
+var ErrPostTypeMismatch = errors.New("post type mismatch")
+
+// UpdateJob lets the author change their vacancy. Pending posts are updated in
+// place and re-sent to admins; published posts get a revision that replaces the
+// channel message only after moderation.
+func (s *JobService) UpdateJob(ctx context.Context, principal *domain.Principal, postID uuid.UUID, req *domain.CreateJobRequest) (*domain.PostWithDetails, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.getOwnPost(ctx, principal, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.PostType != domain.PostTypeVacancy {
+		return nil, ErrPostTypeMismatch
+	}
+
+	post.CompanyName = req.Company
+	post.CompanyContact = req.Contact
+	post.Title = req.Title
+	post.Level = req.Level
+	post.Type = req.Type
+	post.Category = req.Category
+	post.SalaryFrom = req.SalaryFrom
+	post.SalaryTo = req.SalaryTo
+	post.Description = req.Description
+	post.ApplyLink = req.ApplyLink
+	post.Language = req.Language
+
+	return post, s.submitChanges(ctx, post)
+}
+
+// UpdateResume is the resume counterpart of UpdateJob
+func (s *JobService) UpdateResume(ctx context.Context, principal *domain.Principal, postID uuid.UUID, req *domain.CreateResumeRequest) (*domain.PostWithDetails, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.getOwnPost(ctx, principal, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.PostType != domain.PostTypeResume {
+		return nil, ErrPostTypeMismatch
+	}
+
+	post.Title = req.Title
+	post.Level = req.Level
+	post.Type = req.Type
+	post.Employment = req.Employment
+	post.SalaryFrom = req.SalaryFrom
+	post.SalaryTo = req.SalaryTo
+	post.ExperienceYears = req.ExperienceYears
+	post.About = req.About
+	post.Contact = req.Contact
+	post.ResumeLink = req.ResumeLink
+	post.Language = req.Language
+
+	return post, s.submitChanges(ctx, post)
+}
+
+// ApproveRevision edits the channel message in place (channel_message_id stays
+// the same) and then applies the revision to its post. If the edit fails the
+// revision stays pending and the error is returned, so the channel and the
+// database don't disagree.
+func (s *JobService) ApproveRevision(ctx context.Context, revisionID uuid.UUID, principal *domain.Principal) (*domain.PostWithDetails, error) {
+	if !s.isAdmin(principal) {
+		return nil, ErrForbidden
+	}
+
+	revision, err := s.revisionRepo.GetByID(ctx, revisionID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if revision.Status != domain.RevisionStatusPending {
+		return nil, ErrInvalidTransition
+	}
+
+	post, err := s.jobRepo.GetWithCompany(ctx, revision.PostID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if post.Status != domain.JobStatusPublished {
+		return nil, ErrInvalidTransition
+	}
+
+	copyPostContent(post, &revision.Content)
+	if s.publisher != nil && post.ChannelMessageID != nil {
+		if err := s.publisher.Edit(ctx, *post.ChannelMessageID, post); err != nil {
+			return nil, err
+		}
+	}
+
+	// The revision is approved only together with the post update, so a
+	// failed save leaves it pending and it can be approved again
+	ok, err := s.revisionRepo.Approve(ctx, revisionID, post)
+	if err == nil && !ok {
+		err = ErrInvalidTransition
+	}
+	if err != nil {
+		// Put the channel message back to what the database holds
+		s.restoreChannelMessage(ctx, revision.PostID)
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// restoreChannelMessage re-renders a published post from the database after
+// an edit of its channel message could not be saved
+func (s *JobService) restoreChannelMessage(ctx context.Context, postID uuid.UUID) {
+	if s.publisher == nil {
+		return
+	}
+	post, err := s.jobRepo.GetWithCompany(ctx, postID)
+	if err != nil {
+		log.Printf("Error loading post %s to restore its channel message: %v", postID, err)
+		return
+	}
+	if post.ChannelMessageID == nil {
+		return
+	}
+	if err := s.publisher.Edit(ctx, *post.ChannelMessageID, post); err != nil {
+		log.Printf("Error restoring channel message of post %s: %v", postID, err)
+	}
+}
+
+// RejectRevision discards a revision; the published post stays as it was
+func (s *JobService) RejectRevision(ctx context.Context, revisionID uuid.UUID, principal *domain.Principal) (*domain.PostWithDetails, error) {
+	if !s.isAdmin(principal) {
+		return nil, ErrForbidden
+	}
+
+	revision, err := s.revisionRepo.GetByID(ctx, revisionID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	ok, err := s.revisionRepo.Review(ctx, revisionID, domain.RevisionStatusRejected)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTransition
+	}
+
+	return s.jobRepo.GetWithCompany(ctx, revision.PostID)
+}
+
+// getOwnPost loads a post and checks that the verified caller is its author
+func (s *JobService) getOwnPost(ctx context.Context, principal *domain.Principal, postID uuid.UUID) (*domain.PostWithDetails, error) {
+	post, err := s.jobRepo.GetWithCompany(ctx, postID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	if principal == nil {
+		return nil, ErrForbidden
+	}
+	user, err := s.userRepo.GetByTelegramID(ctx, principal.TelegramID)
+	if err != nil || post.UserID == nil || *post.UserID != user.ID {
+		return nil, ErrForbidden
+	}
+	return post, nil
+}
+
+func (s *JobService) submitChanges(ctx context.Context, post *domain.PostWithDetails) error {
+	switch post.Status {
+	case domain.JobStatusPending:
+		// Not moderated yet: update in place and show admins the new version
+		if err := s.savePostContent(ctx, post); err != nil {
+			return err
+		}
+		if s.notifier != nil {
+			_ = s.notifier.NotifyNewJob(ctx, post)
+		}
+		return nil
+
+	case domain.JobStatusPublished:
+		revision := &domain.PostRevision{
+			PostID:  post.ID,
+			Content: *post,
+		}
+		if err := s.revisionRepo.Create(ctx, revision); err != nil {
+			return err
+		}
+		if s.notifier != nil {
+			_ = s.notifier.NotifyRevision(ctx, revision)
+		}
+		return nil
+
+	default:
+		return ErrInvalidTransition
+	}
+}
+
+func (s *JobService) savePostContent(ctx context.Context, post *domain.PostWithDetails) error {
+	if post.CompanyID != nil {
+		company := &domain.Company{
+			ID:      *post.CompanyID,
+			Name:    post.CompanyName,
+			Contact: post.CompanyContact,
+		}
+		if err := s.companyRepo.Update(ctx, company); err != nil {
+			return err
+		}
+	}
+	return s.jobRepo.UpdateContent(ctx, &post.Post)
+}
+
+// copyPostContent copies the author-editable fields from src to dst
+func copyPostContent(dst *domain.PostWithDetails, src *domain.PostWithDetails) {
+	dst.CompanyName = src.CompanyName
+	dst.CompanyContact = src.CompanyContact
+	dst.Title = src.Title
+	dst.Level = src.Level
+	dst.Type = src.Type
+	dst.Category = src.Category
+	dst.SalaryFrom = src.SalaryFrom
+	dst.SalaryTo = src.SalaryTo
+	dst.Description = src.Description
+	dst.ApplyLink = src.ApplyLink
+	dst.Language = src.Language
+	dst.ExperienceYears = src.ExperienceYears
+	dst.Employment = src.Employment
+	dst.About = src.About
+	dst.ResumeLink = src.ResumeLink
+	dst.Contact = src.Contact
+}
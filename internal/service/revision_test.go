@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/testutil"
+)
+
+// publish approves a vacancy and runs the publish queue once
+func (pt *publishTest) publish(t *testing.T) uuid.UUID {
+	t.Helper()
+
+	postID := pt.approve(t, domain.PlacementStandard)
+	if _, err := pt.service.ProcessPublishQueue(context.Background()); err != nil {
+		t.Fatalf("ProcessPublishQueue: %v", err)
+	}
+	return postID
+}
+
+// edit submits a new title for a published vacancy and returns its revision ID
+func (pt *publishTest) edit(t *testing.T, postID uuid.UUID, title string) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+
+	author := &domain.Principal{Kind: domain.PrincipalBot, TelegramID: 2002}
+	_, err := pt.service.UpdateJob(ctx, author, postID, &domain.CreateJobRequest{
+		Company:     "Acme",
+		Contact:     "@acme_hr",
+		Title:       title,
+		Level:       domain.JobLevelSenior,
+		Type:        domain.JobTypeRemote,
+		Category:    domain.JobCategoryDev,
+		Description: "Backend services in Go",
+		ApplyLink:   "https://example.com/apply",
+		Language:    "en",
+	})
+	if err != nil {
+		t.Fatalf("editing post: %v", err)
+	}
+
+	var revisionID uuid.UUID
+	err = pt.db.Pool.QueryRow(ctx,
+		`SELECT id FROM post_revisions WHERE post_id = $1 ORDER BY created_at DESC LIMIT 1`, postID,
+	).Scan(&revisionID)
+	if err != nil {
+		t.Fatalf("reading revision: %v", err)
+	}
+	return revisionID
+}
+
+func TestNewRevisionSupersedesPending(t *testing.T) {
+	pt := newPublishTest(t)
+	postID := pt.publish(t)
+	ctx := context.Background()
+
+	first := pt.edit(t, postID, "Go developer (remote)")
+	second := pt.edit(t, postID, "Senior Go developer")
+
+	revision, err := pt.service.revisionRepo.GetByID(ctx, first)
+	if err != nil {
+		t.Fatalf("loading revision: %v", err)
+	}
+	if revision.Status != domain.RevisionStatusSuperseded {
+		t.Errorf("older revision status = %s, want superseded", revision.Status)
+	}
+	if _, err := pt.service.ApproveRevision(ctx, first, pt.admin); err != ErrInvalidTransition {
+		t.Errorf("approving a superseded revision = %v, want ErrInvalidTransition", err)
+	}
+
+	post, err := pt.service.ApproveRevision(ctx, second, pt.admin)
+	if err != nil {
+		t.Fatalf("ApproveRevision: %v", err)
+	}
+	if post.Title != "Senior Go developer" {
+		t.Errorf("title = %q, want the latest edit", post.Title)
+	}
+}
+
+func TestFailedChannelEditKeepsRevisionPending(t *testing.T) {
+	pt := newPublishTest(t)
+	postID := pt.publish(t)
+	revisionID := pt.edit(t, postID, "Senior Go developer")
+	ctx := context.Background()
+
+	pt.fake.Handle("editMessageText", func(url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{ErrorCode: 400, Description: "Bad Request: message to edit not found"}
+	})
+	if _, err := pt.service.ApproveRevision(ctx, revisionID, pt.admin); err == nil {
+		t.Fatal("ApproveRevision succeeded although the channel edit failed")
+	}
+
+	revision, err := pt.service.revisionRepo.GetByID(ctx, revisionID)
+	if err != nil {
+		t.Fatalf("loading revision: %v", err)
+	}
+	if revision.Status != domain.RevisionStatusPending {
+		t.Errorf("revision status = %s, want pending", revision.Status)
+	}
+	post, err := pt.service.GetJob(ctx, postID)
+	if err != nil {
+		t.Fatalf("loading post: %v", err)
+	}
+	if post.Title != "Go developer" {
+		t.Errorf("title = %q, want the published one", post.Title)
+	}
+
+	// Once Telegram accepts the edit, the same revision can be approved
+	pt.fake.Handle("editMessageText", func(params url.Values) testutil.BotAPIResponse {
+		return testutil.BotAPIResponse{Result: pt.fake.NewMessage(params.Get("chat_id"))}
+	})
+	if _, err := pt.service.ApproveRevision(ctx, revisionID, pt.admin); err != nil {
+		t.Fatalf("ApproveRevision: %v", err)
+	}
+}
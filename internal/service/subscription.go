@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"telegram-job/internal/domain"
+	"telegram-job/internal/repository"
+)
+
+const (
+	maxSubscriptionsPerUser = 10
+	alertLease              = 2 * time.Minute // How long claimed alerts are hidden from other workers
+)
+
+var (
+	ErrTooManySubscriptions = errors.New("too many subscriptions")
+	ErrEmptySubscription    = errors.New("subscription has no filters")
+)
+
+// SubscriptionService stores job alert filters. Matching happens in the
+// database when a post is published; the bot forwards the queued alerts.
+type SubscriptionService struct {
+	subRepo *repository.SubscriptionRepository
+}
+
+func NewSubscriptionService(subRepo *repository.SubscriptionRepository) *SubscriptionService {
+	return &SubscriptionService{subRepo: subRepo}
+}
+
+func (s *SubscriptionService) Subscribe(ctx context.Context, telegramID int64, sub *domain.Subscription) error {
+	var keywords []string
+	for _, keyword := range sub.Keywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	sub.Keywords = keywords
+
+	if sub.Frequency == "" {
+		sub.Frequency = domain.AlertInstant
+	}
+
+	// A digest without filters summarizes every post; instant alerts need one
+	if sub.Frequency == domain.AlertInstant && sub.PostType == nil && sub.Level == nil &&
+		sub.Type == nil && sub.Category == nil && sub.MinSalary == nil && len(sub.Keywords) == 0 {
+		return ErrEmptySubscription
+	}
+
+	count, err := s.subRepo.CountByTelegramID(ctx, telegramID)
+	if err != nil {
+		return err
+	}
+	if count >= maxSubscriptionsPerUser {
+		return ErrTooManySubscriptions
+	}
+
+	sub.TelegramID = telegramID
+	return s.subRepo.Create(ctx, sub)
+}
+
+func (s *SubscriptionService) List(ctx context.Context, telegramID int64) ([]domain.Subscription, error) {
+	return s.subRepo.ListByTelegramID(ctx, telegramID)
+}
+
+func (s *SubscriptionService) Unsubscribe(ctx context.Context, telegramID int64, id uuid.UUID) error {
+	ok, err := s.subRepo.Delete(ctx, telegramID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UnsubscribeAll drops everything of a user who can no longer be messaged
+func (s *SubscriptionService) UnsubscribeAll(ctx context.Context, telegramID int64) error {
+	return s.subRepo.DeleteByTelegramID(ctx, telegramID)
+}
+
+// ClaimAlerts returns up to limit alerts to send
+func (s *SubscriptionService) ClaimAlerts(ctx context.Context, limit int) ([]domain.JobAlert, error) {
+	return s.subRepo.ClaimAlerts(ctx, time.Now().UTC(), alertLease, limit)
+}
+
+func (s *SubscriptionService) AlertDone(ctx context.Context, id uuid.UUID) error {
+	return s.subRepo.AlertDone(ctx, id)
+}
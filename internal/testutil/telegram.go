@@ -0,0 +1,146 @@
+// Package testutil holds helpers shared by tests: a fake Telegram Bot API
+// server and a throwaway PostgreSQL schema with the migrations applied.
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// FakeBotToken is the token tests use; the fake server accepts any token
+const FakeBotToken = "123456:TEST"
+
+// BotAPICall is one request the code under test made to the Bot API
+type BotAPICall struct {
+	Method string
+	Params url.Values
+}
+
+// BotAPIResponse is what the fake server answers to a call
+type BotAPIResponse struct {
+	Result      interface{}
+	ErrorCode   int
+	Description string
+	RetryAfter  int
+}
+
+// FakeBotAPI is an httptest server speaking the Bot API protocol. It records
+// every call; methods answer true (or a message for send* and editMessage*
+// methods) unless a handler was set with Handle.
+type FakeBotAPI struct {
+	server *httptest.Server
+
+	mu            sync.Mutex
+	calls         []BotAPICall
+	handlers      map[string]func(params url.Values) BotAPIResponse
+	nextMessageID int
+}
+
+func NewFakeBotAPI(t *testing.T) *FakeBotAPI {
+	t.Helper()
+
+	f := &FakeBotAPI{
+		handlers:      make(map[string]func(params url.Values) BotAPIResponse),
+		nextMessageID: 100,
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// API returns a client of the fake server, as tgbotapi.NewBotAPI would for Telegram
+func (f *FakeBotAPI) API(t *testing.T) *tgbotapi.BotAPI {
+	t.Helper()
+
+	api, err := tgbotapi.NewBotAPIWithAPIEndpoint(FakeBotToken, f.server.URL+"/bot%s/%s")
+	if err != nil {
+		t.Fatalf("creating bot API client: %v", err)
+	}
+	return api
+}
+
+// Handle sets the answer to a method, replacing the default one
+func (f *FakeBotAPI) Handle(method string, handler func(params url.Values) BotAPIResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[method] = handler
+}
+
+// Calls returns the recorded calls of a method, oldest first
+func (f *FakeBotAPI) Calls(method string) []BotAPICall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []BotAPICall
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (f *FakeBotAPI) serve(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	f.mu.Lock()
+	f.calls = append(f.calls, BotAPICall{Method: method, Params: r.PostForm})
+	handler := f.handlers[method]
+	f.mu.Unlock()
+
+	var resp BotAPIResponse
+	if handler != nil {
+		resp = handler(r.PostForm)
+	} else {
+		resp = f.defaultResponse(method, r.PostForm)
+	}
+
+	body := map[string]interface{}{"ok": resp.ErrorCode == 0}
+	if resp.ErrorCode == 0 {
+		body["result"] = resp.Result
+	} else {
+		body["error_code"] = resp.ErrorCode
+		body["description"] = resp.Description
+		if resp.RetryAfter > 0 {
+			body["parameters"] = map[string]int{"retry_after": resp.RetryAfter}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func (f *FakeBotAPI) defaultResponse(method string, params url.Values) BotAPIResponse {
+	switch {
+	case method == "getMe":
+		return BotAPIResponse{Result: map[string]interface{}{"id": 123456, "is_bot": true, "first_name": "Test", "username": "test_bot"}}
+	case strings.HasPrefix(method, "send"), strings.HasPrefix(method, "editMessage"):
+		return BotAPIResponse{Result: f.NewMessage(params.Get("chat_id"))}
+	default:
+		return BotAPIResponse{Result: true}
+	}
+}
+
+// NewMessage builds the Message result of a send* call with a new message ID
+func (f *FakeBotAPI) NewMessage(chatID string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextMessageID++
+	id, _ := json.Number(chatID).Int64()
+	return map[string]interface{}{
+		"message_id": f.nextMessageID,
+		"date":       0,
+		"chat":       map[string]interface{}{"id": id, "type": "private"},
+	}
+}
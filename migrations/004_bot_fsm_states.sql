@@ -0,0 +1,10 @@
+-- Persistent bot FSM state (survives restarts, shared between bot replicas)
+CREATE TABLE bot_fsm_states (
+    telegram_id BIGINT PRIMARY KEY,
+    state INT NOT NULL DEFAULT 0,
+    language VARCHAR(5) NOT NULL DEFAULT '',
+    draft JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_bot_fsm_states_updated_at ON bot_fsm_states(updated_at);
@@ -0,0 +1,3 @@
+-- Draft timestamps for expiry and idle reminders
+ALTER TABLE bot_fsm_states ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE bot_fsm_states ADD COLUMN reminded_at TIMESTAMPTZ;
@@ -0,0 +1,13 @@
+-- Author edits of published posts, moderated before they replace the channel message
+CREATE TYPE revision_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE post_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    content JSONB NOT NULL,
+    status revision_status NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    reviewed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_post_revisions_post_id ON post_revisions(post_id);
@@ -0,0 +1,2 @@
+-- Reason for the current status (e.g. why a post was archived)
+ALTER TABLE posts ADD COLUMN status_reason TEXT;
@@ -0,0 +1,2 @@
+-- Persist the "editing a single field from the preview" flag
+ALTER TABLE bot_fsm_states ADD COLUMN editing BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+-- Admin waiting to type a free-text rejection reason for this post
+ALTER TABLE bot_fsm_states ADD COLUMN reject_post_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,12 @@
+-- Audit log of post status transitions
+CREATE TABLE post_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    actor_telegram_id BIGINT, -- NULL for system actions (e.g. expiry)
+    from_status job_status NOT NULL,
+    to_status job_status NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_post_events_post_id ON post_events(post_id, created_at);
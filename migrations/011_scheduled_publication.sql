@@ -0,0 +1,4 @@
+-- Approved posts waiting for scheduled publication
+ALTER TABLE posts ADD COLUMN publish_at TIMESTAMPTZ;
+
+CREATE INDEX idx_posts_publish_at ON posts(publish_at) WHERE status = 'approved';
@@ -0,0 +1,16 @@
+-- Durable queue of approved posts waiting to be sent to the channel
+CREATE TABLE publish_outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    actor_telegram_id BIGINT, -- admin who approved the post
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ, -- NULL once the task has failed for good
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_publish_outbox_next_attempt ON publish_outbox(next_attempt_at) WHERE next_attempt_at IS NOT NULL;
+
+-- Posts left in approved state (scheduled or after a failed publish) go through the queue
+INSERT INTO publish_outbox (post_id, next_attempt_at)
+SELECT id, COALESCE(publish_at, now()) FROM posts WHERE status = 'approved';
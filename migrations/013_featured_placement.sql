@@ -0,0 +1,9 @@
+-- Placement tier chosen at approval; featured posts are pinned in the channel for a while
+CREATE TYPE placement_tier AS ENUM ('standard', 'featured');
+
+ALTER TABLE posts ADD COLUMN tier placement_tier NOT NULL DEFAULT 'standard';
+ALTER TABLE posts ADD COLUMN pinned_at TIMESTAMPTZ;
+ALTER TABLE posts ADD COLUMN pin_until TIMESTAMPTZ;
+ALTER TABLE posts ADD COLUMN unpinned_at TIMESTAMPTZ;
+
+CREATE INDEX idx_posts_pin_until ON posts(pin_until) WHERE pinned_at IS NOT NULL AND unpinned_at IS NULL;
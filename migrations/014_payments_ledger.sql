@@ -0,0 +1,9 @@
+-- Payments ledger: link payments to posts and record how they were made
+ALTER TABLE payments ADD COLUMN post_id UUID REFERENCES posts(id) ON DELETE SET NULL;
+ALTER TABLE payments ADD COLUMN method TEXT NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN tier placement_tier;
+ALTER TABLE payments ADD COLUMN recorded_by BIGINT; -- admin telegram ID
+ALTER TABLE payments ADD COLUMN paid_at TIMESTAMPTZ;
+
+CREATE INDEX idx_payments_post_id ON payments(post_id);
+CREATE INDEX idx_payments_company_id ON payments(company_id);
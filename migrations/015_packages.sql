@@ -0,0 +1,20 @@
+-- Prepaid packages: N standard posts bought up front, used one by one
+CREATE TABLE packages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    total_credits INT NOT NULL CHECK (total_credits >= 0),
+    used_credits INT NOT NULL DEFAULT 0 CHECK (used_credits >= 0),
+    expires_at TIMESTAMPTZ,
+    granted_by BIGINT, -- admin telegram ID
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (used_credits <= total_credits)
+);
+
+CREATE INDEX idx_packages_user_id ON packages(user_id);
+
+-- One credit per post, so a post is never charged twice
+CREATE TABLE package_usages (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    package_id UUID NOT NULL REFERENCES packages(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,4 @@
+-- Telegram Payments: the charge ID makes a successful_payment idempotent
+ALTER TABLE payments ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX idx_payments_external_id ON payments(external_id) WHERE external_id IS NOT NULL;
@@ -0,0 +1,22 @@
+-- Promo codes: percentage or fixed discounts on placements
+CREATE TYPE discount_kind AS ENUM ('percent', 'fixed');
+
+CREATE TABLE promo_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code TEXT NOT NULL UNIQUE,        -- upper case
+    kind discount_kind NOT NULL,
+    value INT NOT NULL CHECK (value > 0), -- percent, or minor units for fixed
+    tier placement_tier,              -- NULL = any tier
+    max_uses INT,                     -- NULL = unlimited
+    used_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ,
+    disabled_at TIMESTAMPTZ,
+    created_by BIGINT,                -- admin telegram ID
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (kind <> 'percent' OR value <= 100)
+);
+
+ALTER TABLE posts ADD COLUMN promo_code_id UUID REFERENCES promo_codes(id);
+
+ALTER TABLE payments ADD COLUMN promo_code_id UUID REFERENCES promo_codes(id);
+ALTER TABLE payments ADD COLUMN discount INT NOT NULL DEFAULT 0; -- minor units taken off the list price
@@ -0,0 +1,26 @@
+-- Job alerts: saved filters and the queue of posts to forward to subscribers
+CREATE TABLE subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    telegram_id BIGINT NOT NULL,
+    post_type post_type,          -- NULL = any
+    level job_level,
+    type job_type,
+    category job_category,
+    min_salary INT,
+    keywords TEXT[] NOT NULL DEFAULT '{}', -- lower case, any of them must occur in the post
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_subscriptions_telegram_id ON subscriptions(telegram_id);
+
+-- Filled in the transaction that publishes a post, drained by the bot
+CREATE TABLE job_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    telegram_id BIGINT NOT NULL,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    claimed_until TIMESTAMPTZ,    -- lease of the worker sending it
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (telegram_id, post_id)
+);
+
+CREATE INDEX idx_job_alerts_created_at ON job_alerts(created_at);
@@ -0,0 +1,14 @@
+-- Digests: subscriptions can be delivered as a daily or weekly summary instead of per post
+CREATE TYPE alert_frequency AS ENUM ('instant', 'daily', 'weekly');
+
+ALTER TABLE subscriptions ADD COLUMN frequency alert_frequency NOT NULL DEFAULT 'instant';
+ALTER TABLE subscriptions ADD COLUMN last_digest_at TIMESTAMPTZ; -- end of the last digest period sent
+
+CREATE INDEX idx_subscriptions_frequency ON subscriptions(frequency);
+
+-- Weekly channel digests, one row per period so it is posted only once
+CREATE TABLE channel_digests (
+    period_start TIMESTAMPTZ PRIMARY KEY,
+    channel_message_id INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
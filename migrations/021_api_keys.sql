@@ -0,0 +1,12 @@
+-- API keys for server-to-server calls. Only a SHA-256 hash of the key is stored;
+-- a key acts as the Telegram user it was issued for.
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    telegram_id BIGINT NOT NULL,
+    created_by BIGINT NOT NULL,   -- admin telegram ID
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
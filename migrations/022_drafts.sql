@@ -0,0 +1,13 @@
+-- Drafts of vacancies and resumes filled in the Telegram Mini App
+CREATE TABLE drafts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    telegram_id BIGINT NOT NULL,
+    post_type post_type NOT NULL,
+    content JSONB NOT NULL DEFAULT '{}',
+    submitted_at TIMESTAMPTZ,     -- set when submission starts, so it happens once
+    post_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_drafts_telegram_id ON drafts(telegram_id);
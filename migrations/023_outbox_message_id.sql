@@ -0,0 +1,3 @@
+-- Channel message sent for a task, so a retry after a failed status update
+-- doesn't post the same vacancy twice
+ALTER TABLE publish_outbox ADD COLUMN channel_message_id INT;
@@ -0,0 +1,2 @@
+-- Last /search of the user (filter and page) for the filter and page buttons
+ALTER TABLE bot_fsm_states ADD COLUMN search JSONB;
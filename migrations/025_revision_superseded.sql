@@ -0,0 +1,2 @@
+-- A revision replaced by a newer edit of the same post before moderation
+ALTER TYPE revision_status ADD VALUE 'superseded';
@@ -0,0 +1,9 @@
+-- Only the latest edit of a post waits for moderation
+UPDATE post_revisions r SET status = 'superseded', reviewed_at = now()
+WHERE status = 'pending'
+  AND EXISTS (
+      SELECT 1 FROM post_revisions newer
+      WHERE newer.post_id = r.post_id AND newer.status = 'pending' AND (newer.created_at, newer.id) > (r.created_at, r.id)
+  );
+
+CREATE UNIQUE INDEX idx_post_revisions_one_pending ON post_revisions(post_id) WHERE status = 'pending';